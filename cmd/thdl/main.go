@@ -8,6 +8,8 @@ import (
 	"github.com/m-kru/go-thdl/internal/args"
 	"github.com/m-kru/go-thdl/internal/doc"
 	"github.com/m-kru/go-thdl/internal/gen"
+	"github.com/m-kru/go-thdl/internal/inst"
+	"github.com/m-kru/go-thdl/internal/lsp"
 	"github.com/m-kru/go-thdl/internal/vet"
 	"github.com/m-kru/go-thdl/internal/vet/rprt"
 )
@@ -41,6 +43,15 @@ func main() {
 		doc.Doc(args.DocArgs)
 	case "gen":
 		gen.Gen(args.GenArgs)
+		if rprt.ViolationCount() > 0 {
+			os.Exit(1)
+		}
+	case "inst":
+		inst.Inst(args.InstArgs)
+	case "lsp":
+		lsp.Lsp(args.LspArgs)
+	case "tb":
+		inst.Tb(args.TbArgs)
 	case "vet":
 		vet.Vet(args.VetArgs)
 		if rprt.ViolationCount() > 0 {
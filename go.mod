@@ -4,4 +4,4 @@ go 1.18
 
 require gopkg.in/yaml.v2 v2.4.0
 
-require golang.org/x/exp v0.0.0-20220428152302-39d4317da171 // indirect
+require golang.org/x/exp v0.0.0-20220428152302-39d4317da171
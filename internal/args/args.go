@@ -45,9 +45,16 @@ func (lm LibMap) Lib(filepath string) string {
 	return ""
 }
 
+// VetProfiles maps rule names to enable status, overriding profiles defaults.
+type VetProfiles struct {
+	RTL map[string]bool
+	TB  map[string]bool
+}
+
 type VetArgs struct {
 	IgnoreList
 	Filepath string
+	Profiles VetProfiles
 }
 
 type HTMLArgs struct {
@@ -68,20 +75,59 @@ type DocArgs struct {
 
 type GenArgs struct {
 	IgnoreList
+	Check    bool
+	Diff     bool
 	ToStdout bool
-	Filepath string
+	// Directory for C headers, empty if C headers are not generated.
+	CHeaderDir string
+	// Directory for Python modules, empty if Python modules are not generated.
+	PythonDir string
+	// Directory for SystemVerilog packages, empty if SystemVerilog packages are not generated.
+	SVDir string
+	// Directory for testbenches, empty if testbenches are not generated.
+	TestbenchDir string
+	// Directory for entity wrappers, empty if wrappers are not generated.
+	WrapperDir string
+	Filepath   string
 }
 
-type Args struct {
-	Cmd     string
-	Debug   bool
-	VetArgs VetArgs
+type InstArgs struct {
+	DocArgs DocArgs
+	// Print component declaration and use component instantiation.
+	Component bool
+	// Print declarations of signals matching entity ports.
+	Signals    bool
+	EntityPath string
+}
+
+type TbArgs struct {
 	DocArgs DocArgs
-	GenArgs GenArgs
+	// Verification framework harness, "osvvm", "vunit" or empty for plain testbench.
+	Harness    string
+	ToStdout   bool
+	EntityPath string
+}
+
+type LspArgs struct {
+	DocArgs DocArgs
+	VetArgs VetArgs
+}
+
+type Args struct {
+	Cmd      string
+	Debug    bool
+	VetArgs  VetArgs
+	DocArgs  DocArgs
+	GenArgs  GenArgs
+	InstArgs InstArgs
+	LspArgs  LspArgs
+	TbArgs   TbArgs
 }
 
 func setFileCfgArgs(fc FileCfg, args *Args) {
 	args.VetArgs.IgnoreList.ignore = fc.Vet.Ignore
+	args.VetArgs.Profiles.RTL = fc.Vet.Profiles.RTL
+	args.VetArgs.Profiles.TB = fc.Vet.Profiles.TB
 
 	args.DocArgs.IgnoreList.ignore = fc.Doc.Ignore
 	args.DocArgs.LibMap.libs = fc.Libs
@@ -9,7 +9,11 @@ type FileCfg struct {
 	Ignore []string
 	Libs   map[string][]string
 	Vet    struct {
-		Ignore []string
+		Ignore   []string
+		Profiles struct {
+			RTL map[string]bool `yaml:"rtl"`
+			TB  map[string]bool `yaml:"tb"`
+		}
 	}
 	Doc struct {
 		Ignore  []string
@@ -48,6 +52,15 @@ func (fc FileCfg) String() string {
 	for _, i := range fc.Vet.Ignore {
 		s.WriteString(fmt.Sprintf("      - %s\n", i))
 	}
+	s.WriteString("    Profiles:\n")
+	s.WriteString("      RTL:\n")
+	for rule, enabled := range fc.Vet.Profiles.RTL {
+		s.WriteString(fmt.Sprintf("        %s: %t\n", rule, enabled))
+	}
+	s.WriteString("      TB:\n")
+	for rule, enabled := range fc.Vet.Profiles.TB {
+		s.WriteString(fmt.Sprintf("        %s: %t\n", rule, enabled))
+	}
 
 	s.WriteString("  Doc:\n")
 	s.WriteString(fmt.Sprintf("    Fusesoc: %t\n", fc.Doc.Fusesoc))
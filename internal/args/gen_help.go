@@ -9,7 +9,32 @@ Usage
   thdl gen [flags] [path/to/file]
 
 Flags
-  -to-stdout  Print to stdout instead of replacing file in place (useful for tests).
+  -c-header dir  Generate C header for each package with generables. Headers are
+                 placed in the dir directory and are named after packages.
+  -check         Check whether generated code is up to date and was not modified by
+                 hand. Files are not modified. Stale code is reported with the diff
+                 between the current and regenerated file content. Exit status is 1
+                 if any stale or modified code is found.
+  -python dir    Generate Python module for each package with generables. Modules
+                 are placed in the dir directory and are named after packages.
+  -sv dir        Generate SystemVerilog package for each package with generables.
+                 Packages are placed in the dir directory and are named after VHDL
+                 packages with the '_sv' suffix.
+  -testbench dir Generate self-checking VHDL-2008 testbench for each enumeration and
+                 record declared in packages. Testbenches are placed in the dir directory
+                 and are named after types with the 'tb_' prefix. Enumeration testbenches
+                 check all literals, record testbenches check random values. Testbenches
+                 check that to_<type>(to_slv(x)) = x and that to_str(x) does not fail.
+  -wrapper dir   Generate wrapper for each entity with ports of generated record types.
+                 Record ports are flattened into std_logic_vector ports with the same
+                 bit layout as in to_slv, other ports and generics are passed through.
+                 Wrappers are placed in the dir directory and are named after entities
+                 with the '_wrapper' suffix. Inout record ports are not supported.
+  -diff          Print unified diff between the current and regenerated file content
+                 instead of replacing file in place.
+  -to-stdout     Print to stdout instead of replacing file in place (useful for tests).
+
+Flags -check, -diff and -to-stdout are mutually exclusive.
 
 If path to file is not provided, thdl will scan all HDL files located in the tree
 of working directory.
@@ -39,9 +64,54 @@ The code generation currently supports following kinds of symbols:
           - function to_slv(status : t_status) return std_logic_vector;
           - function to_str(status : t_status) return string;
 
+        Flags:
+          - count     Generate constant C_STATUS_COUNT with the number of literals.
+          - from-str  Generate function from_str(s : string) return t_status.
+                      The string must be equal to the literal name.
+          - is-valid  Generate function is_valid_status(slv : std_logic_vector)
+                      returning false for values not mapping to any literal.
+          - succ      Generate functions succ and pred returning the next and
+                      the previous literal. Both functions wrap around.
+          - to-int    Generate function to_integer(status : t_status) return natural
+                      returning the position of the literal.
+
+        Parameters:
+          - encoding  Encoding type. Valid encodings are: explicit, gray, johnson,
+                      one-cold, one-hot, sequential. The default encoding is sequential.
+          - width     Width of the std_logic_vector. Must not be lower than the width
+                      required by the encoding. Supported only for explicit, gray and
+                      sequential encodings.
+
+        In the case of explicit encoding, value of each literal must be provided with
+        the 'value' literal argument. Literal arguments are provided at the end of
+        the line with particular literal. They are prepended with '--thdl:' tag.
+        Value can be decimal, hexadecimal (0x prefix) or binary (0b prefix).
+        Example:
+          --thdl:gen encoding=explicit width=8
+          type t_opcode is (
+             NOP,   --thdl: value=0x00
+             LOAD,  --thdl: value=0x1A
+             STORE  --thdl: value=0x2B
+          );
+
+    - array type
+
+        Example:
+          --thdl:gen
+          type t_status_arr is array (0 to 3) of t_status;
+
+        Thdl will generate following functions:
+          - function to_status_arr(slv : std_logic_vector(7 downto 0)) return t_status_arr;
+          - function to_slv(status_arr : t_status_arr) return std_logic_vector;
+          - function to_str(status_arr : t_status_arr) return string;
+
         Parameters:
-          - encoding  Encoding type. Valid encodings are: gray, one-hot, sequential.
-                      The default encoding is sequential.
+          - order  Order of elements in the std_logic_vector. Valid orders are: lsb-first,
+                   msb-first. In the msb-first order the left element of the array is placed
+                   at the most significant bits. The default order is msb-first.
+
+        Only constrained arrays are supported. The element type can be standard type
+        or type also marked for generation, the same way as for record fields.
 
     - record type
 
@@ -53,32 +123,123 @@ The code generation currently supports following kinds of symbols:
              crc     : std_logic_vector(7 downto 0);
           end record;
 
-        Thdl will generate following functions:
+        Thdl will generate following constants and functions:
+          - constant C_DATA_WIDTH : natural := 41;
+          - constant C_DATA_<FIELD>_HI : natural; (one per field, for example C_DATA_CRC_HI)
+          - constant C_DATA_<FIELD>_LO : natural; (one per field, for example C_DATA_CRC_LO)
           - function to_data(slv : std_logic_vector(40 downto 0)) return t_data;
           - function to_slv(data : t_data) return std_logic_vector;
           - function to_str(data : t_data) return string;
 
         Flags:
+          - equal      Generate the t_data_mask record with boolean field per record field
+                       and function equal(a, b : t_data; mask : t_data_mask) return boolean,
+                       comparing only fields selected by the mask.
+          - init       Generate constant C_DATA_INIT : t_data with default values of all fields.
+          - masked     Generate the t_data_mask record, the C_DATA_INIT constant and function
+                       to_data_masked(data : t_data; mask : t_data_mask) return t_data,
+                       setting fields not selected by the mask to the init values.
           - no-to-str  Do not generate to_str function.
+          - regmap     Generate register map, see the description below.
+
+        Parameters:
+          - align      Width of the std_logic_vector is padded to the multiple of align bits.
+                       Padding bits are added at the most significant bits.
+          - base       Base address of the register map. The default base is 0x0.
+          - bus        Bus of the register map. Valid buses are: axi4lite, wishbone.
+                       The default bus is axi4lite.
+          - endian     Order of units in the serialized array. Valid orders are: big, little.
+                       In the big order the unit with index 0 holds the most significant bits.
+                       The default order is big.
+          - order      Order of fields in the std_logic_vector. Valid orders are: lsb-first,
+                       msb-first. In the msb-first order the first field of the record is placed
+                       at the most significant bits. The default order is msb-first.
+          - serialize  Generate the t_data_bytes or t_data_words array type with functions
+                       to_bytes/from_bytes or to_words/from_words converting the record to array
+                       of bytes or 32-bit words. Valid arguments are: bytes, words.
+                       The record is placed at the least significant bits of the array,
+                       remaining bits are zeros.
+
+        Field arguments (described below) pad and offset control the placement of
+        particular field:
+          - pad     Number of unused bits following the field in the packing order.
+          - offset  Index of the least significant bit of the field. The offset must not
+                    overlap fields, or padding, preceding the field in the packing order.
+        Unused bits are set to '0' in the to_slv function.
+        Example:
+          --thdl:gen order=lsb-first align=32
+          type t_ctrl is record
+             en   : std_logic;
+             mode : std_logic_vector(1 downto 0); --thdl: pad=5
+             irq  : std_logic; --thdl: offset=24
+          end record;
 
         By default thdl will handle all fields of standard types or of types that are also
-        marked for generation and are located within the same scope (the scope is currently
-        limited to the design unit). To handle foreign types one needs to provide additional
-        information via field arguments. Field arguments are provided at the end of the line
+        marked for generation. Types marked for generation are first looked for in the same
+        design unit, and then in packages located in the tree of working directory. If a type
+        is declared in multiple packages, then use clauses of the file, for example
+        'use work.pkg.all;', are used for disambiguation. To handle other foreign types
+        one needs to provide additional information via field arguments. Field arguments are provided at the end of the line
         with particular field. They are prepended with '--thdl:' tag.
         Example:
           --thdl:gen
           type t_rec is record
              field : t_foreign; --thdl: width=N [to-type=name] [to-slv=name] [to-str=name]
           end record;
-        Width is the only mandatory argument. N must be greater than 0. To-type is the name
-        of the function used for the conversion from the std_logic_vector to the t_foreign.
+        Width is the only mandatory argument for foreign types. N must be greater than 0.
+        To-type is the name of the function used for the conversion from the std_logic_vector
+        to the t_foreign.
         To-slv is the name of the function used for the conversion from the t_foreign to the
         std_logic_vector. To-str is the name of the function used for conversion from the
         t_foreign to the string. When names of these functions are not provided, then
         'to_foreign', 'to_slv' and 'to_str' are used respectively. Note that there are no
         whitespaces between argument name, its value and the '=' character.
 
+        Bounds of the vector field ranges and the width argument might be expressions.
+        Supported operators are '+', '-', '*', '/', 'mod', 'rem', '**' and parentheses.
+        Expressions might refer integer, natural and positive constants, and package
+        generics with default values, declared in packages in the same file or in any
+        file within the working directory tree. If a constant is declared in multiple
+        packages, then selected name, for example 'pkg.DATA_W', must be used.
+        Example:
+          constant DATA_W : natural := 32;
+
+          --thdl:gen
+          type t_rec is record
+             data : std_logic_vector(DATA_W - 1 downto 0);
+             strb : std_logic_vector(DATA_W / 8 - 1 downto 0);
+          end record;
+
+        Record with the regmap flag describes register bank with 32-bit data bus.
+        Each field is a single register, supported field types are std_logic, std_ulogic,
+        std_logic_vector, unsigned and signed, with width up to 32 bits. Registers are
+        described with following field arguments:
+          - access  Access type. Valid types are: rw (read-write), ro (read-only, driven by
+                    the hardware), wo (write-only, read as zero) and w1c (write one to clear,
+                    set by the hardware). The default access is rw.
+          - addr    Address offset relative to the base address, must be aligned to 4 bytes.
+                    By default the register is placed after the previous one.
+          - reset   Reset value. The default reset value is 0.
+        Example:
+          --thdl:gen regmap bus=axi4lite base=0x40000000
+          type t_ctrl is record
+             enable : std_logic;                    --thdl: reset=1
+             status : std_logic_vector(7 downto 0); --thdl: access=ro
+             irq    : std_logic_vector(3 downto 0); --thdl: access=w1c addr=0x10
+          end record;
+
+        Thdl will generate following constants in the package:
+          - constant C_CTRL_BASE : std_logic_vector(31 downto 0);
+          - constant C_CTRL_<FIELD>_ADDR : std_logic_vector(31 downto 0); (one per field)
+          - constant C_CTRL_RESET : t_ctrl;
+        and following files in the directory of the VHDL file:
+          - ctrl_regs.vhd  The ctrl_regs entity implementing the bus slave. Register values
+                           are provided with the regs_o port, values of ro registers and bits
+                           setting w1c registers are taken from the regs_i port.
+          - ctrl_regs.h    C header with addresses, widths, masks and reset values.
+          - ctrl_regs.md   Markdown register table.
+        Register map records must be declared in packages.
+
 
 Arguments passing
 -----------------
@@ -91,6 +252,107 @@ Examples:
   record_field : t_external_type; --thdl: width=8
 
 
+Generated code
+--------------
+
+The generated code is placed between '--thdl:start' and '--thdl:end' lines.
+The '--thdl:start' line contains checksum of the generated code, for example:
+  --thdl:start checksum=af4dad41
+Any modification of the generated code is lost during the next generation.
+To detect such modifications, the checksum is verified by the 'thdl gen -check'
+and by the vet command. If the generated code regenerated from the current
+declarations matches the checksum, then modified lines are reported.
+Otherwise, only the '--thdl:start' line is reported.
+
+In packages, declarations are generated at the end of the package and definitions
+are generated at the end of the package body. Types can also be declared in the
+architecture declarative region. In such a case, both declarations and definitions
+are generated right before the architecture 'begin' line.
+
+
+C headers
+---------
+
+C header contains code for all generables declared in the package:
+  - enumeration type  WIDTH macro and enum with values equal to the VHDL encoding.
+                      Values of enumerations wider than 31 bits are defined as macros.
+  - record type       WIDTH macro, SHIFT, WIDTH and MASK macros for each field,
+                      struct and inline pack and unpack functions. Bit positions
+                      are the same as in the to_slv function. Masks and functions are
+                      not generated for records wider than 64 bits.
+  - array type        WIDTH, LENGTH and ELEMENT_WIDTH macros.
+The 't_' prefix is removed from the C names. Integer record fields are signed,
+all other fields are unsigned.
+Example for record 't_ctrl' with field 'en':
+  #define CTRL_EN_SHIFT 0
+  #define CTRL_EN_WIDTH 1
+  #define CTRL_EN_MASK UINT8_C(0x01)
+  struct ctrl { uint8_t en; };
+  static inline uint8_t ctrl_pack(const struct ctrl *ctrl);
+  static inline void ctrl_unpack(uint8_t word, struct ctrl *ctrl);
+
+Flags -check, -diff and -to-stdout apply also to C headers.
+
+
+Python modules
+--------------
+
+Python module contains code for all generables declared in the package:
+  - enumeration type  WIDTH constant and IntEnum class with values equal to the
+                      VHDL encoding.
+  - record type       WIDTH constant and dataclass with to_int and from_int methods.
+                      Bit positions are the same as in the to_slv function.
+  - array type        WIDTH, LENGTH and ELEMENT_WIDTH constants.
+Class names are derived from type names, for example 'StatusArr' for 't_status_arr'.
+Record fields of enumeration and record types generated in the same package are
+represented by the generated classes. Integer fields are signed, boolean fields are
+bool and all other fields are unsigned int. Modules can be directly used in cocotb
+testbenches, for example:
+  hdr = Hdr.from_int(int(dut.hdr_o.value))
+  dut.hdr_i.value = Hdr(kind=Kind.DATA, last=True, len=8).to_int()
+
+Flags -check, -diff and -to-stdout apply also to Python modules.
+
+
+SystemVerilog packages
+----------------------
+
+SystemVerilog package contains types for all generables declared in the package:
+  - enumeration type  typedef enum logic [N-1:0] with values equal to the VHDL encoding.
+                      Literal names are prefixed with the type name, for example
+                      'STATUS_OK' for the 'OK' literal of the 't_status' type, as
+                      SystemVerilog does not allow overloading of enumeration literals.
+  - record type       WIDTH localparam and typedef struct packed. Bit positions are
+                      the same as in the to_slv function. Unused bits are declared
+                      as '_padN' members.
+  - array type        WIDTH localparam and typedef of the packed array. The element
+                      placed at the most significant bits has the left index.
+Type names are derived from VHDL type names, for example 'status_t' for 't_status'.
+As the layout is identical, the flat std_logic_vector from the VHDL to_slv function
+can be directly assigned to the SystemVerilog type and vice versa.
+
+Flags -check, -diff and -to-stdout apply also to SystemVerilog packages.
+
+
+Entity wrappers
+---------------
+
+Vendor block design tools and Verilog top levels can't use VHDL records, so
+entities with ports of generated record types need wrappers. The wrapper has
+the same generics and ports as the wrapped entity, except record ports, which
+are replaced with std_logic_vector ports of the record width. For example,
+the 'cfg_i : in t_cfg' port is replaced with:
+
+  cfg_i : in std_logic_vector(C_CFG_WIDTH - 1 downto 0)
+
+The wrapper converts flattened ports with the to_<type> and to_slv functions,
+so the bit layout is the same as in the to_slv function. The wrapped entity is
+instantiated from the work library. Entities are wrapped only when the -wrapper
+flag is provided.
+
+Flags -check, -diff and -to-stdout apply also to entity wrappers.
+
+
 Naming symbols
 --------------
 
@@ -104,4 +366,6 @@ Constraints
 
 File may contain multile design symbols, however package body must always
 follow package declaration.
+Generables declared in architectures are not included in C headers, Python
+modules and SystemVerilog packages.
 `
@@ -16,6 +16,9 @@ The commands are:
   doc   Show or generate documentation.
   gen   Generate HDL files by processing sources.
   help  Print more information about a specific command.
+  inst  Print entity instantiation template.
+  lsp   Run language server.
+  tb    Generate testbench skeleton for entity.
   ver   Print thdl version.
   vet   Check for likely mistakes.
 
@@ -63,6 +66,13 @@ settings.
   vet:
     ignore:
       - some/ignored/dir
+    # Rules enable status overriding profiles defaults.
+    # Run 'thdl help vet' for the list of rules.
+    profiles:
+      rtl:
+        signal-never-read: false
+      tb:
+        testbench-missing-finish: false
 `
 
 func printHelp() {
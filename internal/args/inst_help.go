@@ -0,0 +1,44 @@
+package args
+
+var instHelpMsg string = `Inst command
+============
+
+Usage
+-----
+
+  thdl inst [flags] entityPath
+
+Flags:
+  -component  Print component declaration and use component instantiation
+              instead of entity instantiation.
+  -debug      Print debug messages.
+  -no-config  Don't read .thdl.yml config file.
+  -signals    Print declarations of signals matching entity ports.
+
+
+Description
+-----------
+
+The inst command prints ready-to-paste instantiation of the entity.
+Entities are found in the same way as symbols in the doc command, so the entity
+path might be the entity name or the entity name preceded by the library name,
+for example 'uart' or 'lib.uart'. Libraries are configured with the 'libs' key
+in the '.thdl.yml' file, and the doc command settings also apply.
+
+Generics and ports are associated with actuals of the same names. Generic types
+and default values, and port modes and types, are placed in trailing comments.
+If both -component and -signals flags are set, then the component declaration
+is printed first, followed by signal declarations and the instantiation.
+
+Example:
+
+  $ thdl inst uart
+  u_uart : entity work.uart
+     generic map (
+        BAUD_RATE => BAUD_RATE -- positive := 115200
+     )
+     port map (
+        clk_i => clk_i, -- in std_logic
+        tx_o  => tx_o   -- out std_logic
+     );
+`
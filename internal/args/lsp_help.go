@@ -0,0 +1,43 @@
+package args
+
+var lspHelpMsg string = `Lsp command
+===========
+
+Usage
+-----
+
+  thdl lsp [flags]
+
+Flags:
+  -debug      Print debug messages to stderr.
+  -no-config  Don't read .thdl.yml config file.
+
+
+Description
+-----------
+
+The lsp command runs Language Server Protocol server communicating over
+stdin and stdout. It allows using thdl within any editor supporting LSP,
+without per-editor plugins. The server must be started in the project's root
+directory, as the working directory tree is scanned for HDL files in the same
+way as for other commands.
+
+Currently following LSP features are supported:
+  - diagnostics - vet violations are published for opened documents,
+    the content of the editor buffer is vetted, not the content of the file,
+  - hover - the documentation comment and the source code of the symbol
+    under the cursor are shown, symbols are found in the same way as in the
+    doc command,
+  - go to definition - jumps to the declaration of the symbol under the cursor.
+
+Symbols for hover and go to definition are scanned only once, at the server
+initialization. Restart the server to take new symbols into account.
+
+Example Neovim configuration:
+
+  vim.lsp.start({
+    name = 'thdl',
+    cmd = {'thdl', 'lsp'},
+    root_dir = vim.fs.dirname(vim.fs.find({'.thdl.yml'}, { upward = true })[1]),
+  })
+`
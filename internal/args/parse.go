@@ -10,7 +10,7 @@ import (
 // isPresent returns true if given argument is present in the argument list.
 func isPresent(arg string) bool {
 	if len(os.Args) <= 2 {
-		return true
+		return false
 	} else {
 		for _, a := range os.Args[2:] {
 			if a == arg {
@@ -48,6 +48,8 @@ func Parse() Args {
 		parseDocArgs(&args)
 	case "gen":
 		parseGenArgs(&args)
+	case "inst":
+		parseInstArgs(&args)
 	case "help":
 		if len(os.Args) < 3 {
 			printHelp()
@@ -60,12 +62,22 @@ func Parse() Args {
 			fmt.Printf(genHelpMsg)
 		} else if os.Args[2] == "help" {
 			fmt.Printf(helpHelpMsg)
+		} else if os.Args[2] == "inst" {
+			fmt.Printf(instHelpMsg)
+		} else if os.Args[2] == "lsp" {
+			fmt.Printf(lspHelpMsg)
+		} else if os.Args[2] == "tb" {
+			fmt.Printf(tbHelpMsg)
 		} else if os.Args[2] == "ver" {
 			fmt.Printf(verHelpMsg)
 		} else if os.Args[2] == "vet" {
 			fmt.Printf(vetHelpMsg)
 		}
 		os.Exit(0)
+	case "lsp":
+		parseLspArgs(&args)
+	case "tb":
+		parseTbArgs(&args)
 	case "ver":
 		fmt.Printf("thdl version %s\n", Version)
 		os.Exit(0)
@@ -136,18 +148,64 @@ func parseDocArgs(args *Args) {
 }
 
 func parseGenArgs(args *Args) {
-	for i, a := range os.Args[2:] {
+	argv := os.Args[2:]
+	for i := 0; i < len(argv); i++ {
+		a := argv[i]
 		switch a {
+		case "-c-header":
+			if i == len(argv)-1 {
+				log.Fatalf("missing directory for '-c-header' flag\n")
+			}
+			i += 1
+			args.GenArgs.CHeaderDir = argv[i]
+		case "-python":
+			if i == len(argv)-1 {
+				log.Fatalf("missing directory for '-python' flag\n")
+			}
+			i += 1
+			args.GenArgs.PythonDir = argv[i]
+		case "-sv":
+			if i == len(argv)-1 {
+				log.Fatalf("missing directory for '-sv' flag\n")
+			}
+			i += 1
+			args.GenArgs.SVDir = argv[i]
+		case "-testbench":
+			if i == len(argv)-1 {
+				log.Fatalf("missing directory for '-testbench' flag\n")
+			}
+			i += 1
+			args.GenArgs.TestbenchDir = argv[i]
+		case "-wrapper":
+			if i == len(argv)-1 {
+				log.Fatalf("missing directory for '-wrapper' flag\n")
+			}
+			i += 1
+			args.GenArgs.WrapperDir = argv[i]
+		case "-check":
+			args.GenArgs.Check = true
+		case "-diff":
+			args.GenArgs.Diff = true
 		case "-to-stdout":
 			args.GenArgs.ToStdout = true
 		default:
-			if i == len(os.Args)-3 {
+			if i == len(argv)-1 {
 				args.GenArgs.Filepath = a
 			} else {
 				log.Fatalf("invalid gen command flag '%s'\n", a)
 			}
 		}
 	}
+
+	modes := 0
+	for _, m := range []bool{args.GenArgs.Check, args.GenArgs.Diff, args.GenArgs.ToStdout} {
+		if m {
+			modes += 1
+		}
+	}
+	if modes > 1 {
+		log.Fatalf("flags '-check', '-diff' and '-to-stdout' are mutually exclusive\n")
+	}
 }
 
 func parseVetArgs(args *Args) {
@@ -162,3 +220,73 @@ func parseVetArgs(args *Args) {
 		}
 	}
 }
+
+func parseInstArgs(args *Args) {
+	for i, a := range os.Args[2:] {
+		switch a {
+		case "-component":
+			args.InstArgs.Component = true
+		case "-debug":
+			args.Debug = true
+		case "-no-config":
+		case "-signals":
+			args.InstArgs.Signals = true
+		default:
+			if i == len(os.Args)-3 {
+				args.InstArgs.EntityPath = a
+			} else {
+				log.Fatalf("invalid inst command flag '%s'\n", a)
+			}
+		}
+	}
+
+	if args.InstArgs.EntityPath == "" {
+		log.Fatalf("missing entity path\n")
+	}
+
+	args.InstArgs.DocArgs = args.DocArgs
+}
+
+func parseTbArgs(args *Args) {
+	for i, a := range os.Args[2:] {
+		switch a {
+		case "-debug":
+			args.Debug = true
+		case "-no-config":
+		case "-osvvm", "-vunit":
+			if args.TbArgs.Harness != "" {
+				log.Fatalf("-osvvm and -vunit flags are mutually exclusive\n")
+			}
+			args.TbArgs.Harness = a[1:]
+		case "-to-stdout":
+			args.TbArgs.ToStdout = true
+		default:
+			if i == len(os.Args)-3 {
+				args.TbArgs.EntityPath = a
+			} else {
+				log.Fatalf("invalid tb command flag '%s'\n", a)
+			}
+		}
+	}
+
+	if args.TbArgs.EntityPath == "" {
+		log.Fatalf("missing entity path\n")
+	}
+
+	args.TbArgs.DocArgs = args.DocArgs
+}
+
+func parseLspArgs(args *Args) {
+	for _, a := range os.Args[2:] {
+		switch a {
+		case "-debug":
+			args.Debug = true
+		case "-no-config":
+		default:
+			log.Fatalf("invalid lsp command flag '%s'\n", a)
+		}
+	}
+
+	args.LspArgs.DocArgs = args.DocArgs
+	args.LspArgs.VetArgs = args.VetArgs
+}
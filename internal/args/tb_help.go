@@ -0,0 +1,49 @@
+package args
+
+var tbHelpMsg string = `Tb command
+==========
+
+Usage
+-----
+
+  thdl tb [flags] entityPath
+
+Flags:
+  -debug      Print debug messages.
+  -no-config  Don't read .thdl.yml config file.
+  -osvvm      Generate OSVVM harness.
+  -to-stdout  Print testbench to stdout instead of writing it to a file.
+  -vunit      Generate VUnit harness.
+
+
+Description
+-----------
+
+The tb command generates testbench skeleton for the entity. The testbench is
+written to the 'tb_<entity>.vhd' file in the working directory. The command
+fails if the file already exists, so hand-written testbenches are never
+overwritten. Entities are found in the same way as in the inst command.
+
+The testbench contains:
+  1. Context clause of the entity file, extended with required libraries.
+  2. Generics mirroring entity generics, so they can be set by the simulator.
+  3. Signal declarations for every entity port.
+  4. Clock generator for each detected clock port.
+  5. Reset sequence for each detected reset port.
+  6. Entity instantiation.
+  7. Main process ending the simulation.
+
+Clock and reset ports are detected with the same naming heuristics as in the
+vet command. A clock port is an input port of a single bit type named 'clk',
+'clock' or 'aclk', optionally with a frequency and prefixed with a domain, for
+example 'clk_i', 'sys_clk_i' or 'clk100_i'. The frequency in the name is
+interpreted in MHz and sets the clock period, otherwise the clock period is
+10 ns. A reset port is an input port of a single bit type with a reset name,
+for example 'rst_i' or 'rstn_i'. Positive resets are initialized with '1',
+and negative resets with '0'. All resets are released after 10 periods of the
+first clock.
+
+The -osvvm and -vunit flags are mutually exclusive. The -vunit flag adds the
+'runner_cfg' generic and the test runner loop with a single test case.
+The -osvvm flag adds test name setting and test reports.
+`
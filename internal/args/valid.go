@@ -2,7 +2,7 @@ package args
 
 func isValidCommand(cmd string) bool {
 	validCommands := [...]string{
-		"doc", "gen", "help", "ver", "vet",
+		"doc", "gen", "help", "inst", "lsp", "tb", "ver", "vet",
 	}
 
 	for i, _ := range validCommands {
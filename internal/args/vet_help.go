@@ -22,8 +22,45 @@ to constant reset value. The vet internally consists of independent,
 orthogonal scopes. Name of each scope reflects the functional scope that
 is actually checked by given scope. Currently following scopes exist:
 - clock - checks mistakes related with clock ports mappings,
+- generated - checks generated code modified by hand,
+- generic - checks mistakes related with generic maps in instantiations,
 - process - checks mistakes related with process coding,
-- reset - checks mistakes related with reset ports mappings and reset if conditions.
+- reset - checks mistakes related with reset ports mappings and reset if conditions,
+- signal - checks mistakes related with signals usage within architectures,
+- testbench - checks mistakes typical for testbenches.
+
+Each scope consists of rules. Rules are grouped into two profiles, 'rtl' and 'tb'.
+The 'tb' profile is applied to testbench design units, the 'rtl' profile is applied
+to all other design units. Testbench is an entity named 'tb', 'tb_*' or '*_tb',
+or an architecture of such entity. Until the first entity or architecture in the
+file, the profile is chosen based on the file name.
+
+Rules (default state in the rtl / tb profile):
+  clock-frequency-mismatch                    on  / on
+  generated-code-edited                       on  / on
+  generic-boolean-mapped-to-integer           on  / on
+  generic-frequency-mismatch                  on  / on
+  generic-width-mapped-to-string              on  / on
+  process-missing-final-wait                  off / on
+  process-missing-sensitivity-list            on  / off
+  process-missing-wait                        on  / on
+  process-signal-missing-in-sensitivity-list  on  / on
+  reset-if-condition                          on  / on
+  reset-port-mapping                          on  / on
+  signal-multiple-drivers                     on  / on
+  signal-never-assigned                       on  / on
+  signal-never-read                           on  / on
+  signal-never-used                           on  / on
+  testbench-missing-finish                    off / on
+
+Rules can be enabled or disabled in the '.thdl.yml' file, for example:
+
+  vet:
+    profiles:
+      rtl:
+        signal-never-read: false
+      tb:
+        process-missing-sensitivity-list: true
 
 Thdl by default ignores some files, as checking them makes no sense.
 If the file path matches one of the ignored patterns, then it won't be checked.
@@ -46,6 +83,54 @@ The clock scope is capable of checking following mistakes:
       clk70_i => clk120_i,
 
 
+Generated scope
+---------------
+
+The generated scope is capable of checking following mistakes:
+
+  Generated code modified by hand.
+
+    Code generated by the gen command is located between '--thdl:start' and '--thdl:end'
+    lines. Modifications of such code are lost during the next generation. Modifications
+    are detected with the checksum placed in the '--thdl:start' line. Generated code without
+    checksum is not checked. Violations of this rule can't be ignored, as the ignore comment
+    would also modify the generated code.
+
+
+Generic scope
+-------------
+
+Similarly to the reset scope, the generic scope relies on generic names.
+Optional 'g_' prefix and '_g' suffix are ignored. Only associations within
+'generic map' are checked.
+
+The generic scope is capable of checking following mistakes:
+
+  Boolean generic mapped to integer literal.
+
+    Generic is treated as boolean if its name starts with 'en_', 'enable_', 'use_', 'has_',
+    'is_', 'do_', 'with_' or 'include_', or ends with '_en', '_enable' or '_enabled'.
+    Examples:
+      g_use_ram => 1,
+      enable_ecc => 0
+      fifo_en_g => 1)
+
+  Width generic mapped to string literal.
+
+    Generic is treated as width if its name ends with '_width' or '_w'.
+    Examples:
+      g_data_width => "32",
+      addr_w => "16")
+
+  Frequency generic of one clock mapped to constant of another clock.
+
+    Clock name is the word preceding '_clk' or '_clock'. Generics and constants with
+    frequency value in the clock name, for example 'clk125', are checked by the clock scope.
+    Examples:
+      g_sys_clk_freq_hz => c_eth_clk_freq_hz,
+      ref_clk_freq => ddr_clk_freq / 2
+
+
 Process scope
 -------------
 
@@ -63,6 +148,19 @@ The process scope is capable of checking following mistakes:
     and I have lost 3 hours on finding the source of malfunction.
 
 
+  Process without sensitivity list and wait statement.
+
+    Such process is an infinite loop and hangs the simulation. Processes calling
+    procedures are not checked, as procedures might contain wait statements.
+
+  Stimulus process without final 'wait;' statement (testbench rule).
+
+    Such process restarts after the last statement and applies the stimulus once again.
+    Processes ending with std.env.finish or std.env.stop call are fine. Processes
+    assigning only single signal are not checked, as these are usually clock or
+    reset generators.
+
+
 Reset scope
 -----------
 
@@ -153,6 +251,48 @@ The reset scope is capable of checking following mistakes:
       if  ( reset_n ) then
 
 
+Signal scope
+------------
+
+The signal scope collects signals declared in the architecture declarative part
+(and in blocks and generate statements) and textually scans the architecture
+statement part for signals reads and assignments. As thdl doesn't know port
+directions, a signal mapped in a port map is treated as both read and assigned.
+
+The signal scope is capable of checking following mistakes:
+
+  Signal never used.
+
+  Signal never read.
+
+    Usually leftover of some debug logic.
+
+  Signal never assigned and without default value.
+
+  Signal with multiple drivers.
+
+    Signal assigned in multiple processes, or assigned in a process and in a concurrent
+    statement, or assigned in multiple concurrent statements. Only assignments to
+    the whole signal are taken into account, assignments to signal elements or slices,
+    for example 'vec(0) <= a;', are not, as different elements might be legally driven
+    by different processes.
+
+To ignore a signal, ignore the line with its declaration. Ignored lines are still
+scanned for signals reads and assignments.
+
+
+Testbench scope
+---------------
+
+The testbench scope is capable of checking following mistakes (testbench rules):
+
+  Testbench architecture without std.env.finish or std.env.stop call.
+
+    Without these calls the simulation ends only when there are no more events, what
+    never happens if there is a free running clock. Calls to VUnit test_runner_cleanup
+    and OSVVM EndOfTestReports are also accepted.
+
+
 Ignoring lines
 --------------
 
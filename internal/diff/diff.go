@@ -0,0 +1,166 @@
+// Package diff implements line based comparison of texts.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Equal Kind = iota
+	Delete
+	Insert
+)
+
+// Op is a single diff operation. For Equal and Delete, A is the line index in the old text.
+// For Equal and Insert, B is the line index in the new text. Unused index equals -1.
+type Op struct {
+	Kind Kind
+	A    int
+	B    int
+}
+
+// Lines returns operations transforming lines a into lines b.
+// Common prefix and suffix are trimmed before finding the longest common subsequence,
+// so comparing long texts with few changes is cheap.
+func Lines(a, b []string) []Op {
+	ops := []Op{}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, Op{Kind: Equal, A: prefix, B: prefix})
+		prefix += 1
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix += 1
+	}
+
+	ops = append(ops, lcs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix)...)
+
+	for i := suffix; i > 0; i-- {
+		ops = append(ops, Op{Kind: Equal, A: len(a) - i, B: len(b) - i})
+	}
+
+	return ops
+}
+
+// lcs returns operations based on the longest common subsequence.
+// offset is added to all line indexes.
+func lcs(a, b []string, offset int) []Op {
+	// lens[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lens := make([][]int, len(a)+1)
+	for i := range lens {
+		lens[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lens[i][j] = lens[i+1][j+1] + 1
+			} else if lens[i+1][j] >= lens[i][j+1] {
+				lens[i][j] = lens[i+1][j]
+			} else {
+				lens[i][j] = lens[i][j+1]
+			}
+		}
+	}
+
+	ops := []Op{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if i < len(a) && j < len(b) && a[i] == b[j] {
+			ops = append(ops, Op{Kind: Equal, A: offset + i, B: offset + j})
+			i += 1
+			j += 1
+		} else if j == len(b) || (i < len(a) && lens[i+1][j] >= lens[i][j+1]) {
+			ops = append(ops, Op{Kind: Delete, A: offset + i, B: -1})
+			i += 1
+		} else {
+			ops = append(ops, Op{Kind: Insert, A: -1, B: offset + j})
+			j += 1
+		}
+	}
+
+	return ops
+}
+
+// Unified returns unified diff of lines a and b with given number of context lines.
+// If there are no differences, empty string is returned.
+func Unified(aName, bName string, a, b []string, context int) string {
+	ops := Lines(a, b)
+
+	// aPos[i] and bPos[i] are the numbers of lines a and b preceding operation i.
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aPos[i+1] = aPos[i]
+		bPos[i+1] = bPos[i]
+		if op.Kind != Insert {
+			aPos[i+1] += 1
+		}
+		if op.Kind != Delete {
+			bPos[i+1] += 1
+		}
+	}
+
+	changes := []int{}
+	for i, op := range ops {
+		if op.Kind != Equal {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	hdr := func(pos int, count int) string {
+		if count == 0 {
+			return fmt.Sprintf("%d,0", pos)
+		}
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", aName, bName))
+
+	for i := 0; i < len(changes); {
+		start := changes[i] - context
+		if start < 0 {
+			start = 0
+		}
+
+		// Merge changes separated by at most 2*context equal lines.
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*context+1 {
+			j += 1
+		}
+
+		end := changes[j] + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"@@ -%s +%s @@\n",
+			hdr(aPos[start], aPos[end]-aPos[start]), hdr(bPos[start], bPos[end]-bPos[start]),
+		))
+		for _, op := range ops[start:end] {
+			switch op.Kind {
+			case Equal:
+				sb.WriteString(" " + a[op.A] + "\n")
+			case Delete:
+				sb.WriteString("-" + a[op.A] + "\n")
+			case Insert:
+				sb.WriteString("+" + b[op.B] + "\n")
+			}
+		}
+
+		i = j + 1
+	}
+
+	return sb.String()
+}
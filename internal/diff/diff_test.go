@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	var tests = []struct {
+		a   []string
+		b   []string
+		ops []Op
+	}{
+		{
+			a:   []string{"a", "b"},
+			b:   []string{"a", "b"},
+			ops: []Op{{Equal, 0, 0}, {Equal, 1, 1}},
+		},
+		{
+			a:   []string{"a", "b", "c"},
+			b:   []string{"a", "x", "c"},
+			ops: []Op{{Equal, 0, 0}, {Delete, 1, -1}, {Insert, -1, 1}, {Equal, 2, 2}},
+		},
+		{
+			a:   []string{"a", "c"},
+			b:   []string{"a", "b", "c"},
+			ops: []Op{{Equal, 0, 0}, {Insert, -1, 1}, {Equal, 1, 2}},
+		},
+		{
+			a:   []string{"a", "b", "c", "d"},
+			b:   []string{"b", "d", "e"},
+			ops: []Op{{Delete, 0, -1}, {Equal, 1, 0}, {Delete, 2, -1}, {Equal, 3, 1}, {Insert, -1, 2}},
+		},
+		{
+			a:   []string{},
+			b:   []string{"a"},
+			ops: []Op{{Insert, -1, 0}},
+		},
+	}
+
+	for i, test := range tests {
+		ops := Lines(test.a, test.b)
+		if !reflect.DeepEqual(ops, test.ops) {
+			t.Errorf("[%d]: got %v; want %v", i, ops, test.ops)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+	b := []string{"1", "2", "x", "4", "5", "6", "7", "8", "9", "10", "11"}
+
+	want := `--- a
++++ b
+@@ -2,3 +2,3 @@
+ 2
+-3
++x
+ 4
+@@ -10,1 +10,2 @@
+ 10
++11
+`
+	if got := Unified("a", "b", a, b, 1); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	want = `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++x
+ 4
+ 5
+ 6
+@@ -8,3 +8,4 @@
+ 8
+ 9
+ 10
++11
+`
+	if got := Unified("a", "b", a, b, 3); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// Changes separated by at most 2*context lines are merged into single hunk.
+	want = `--- a
++++ b
+@@ -1,10 +1,11 @@
+ 1
+ 2
+-3
++x
+ 4
+ 5
+ 6
+ 7
+ 8
+ 9
+ 10
++11
+`
+	if got := Unified("a", "b", a, b, 4); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	if got := Unified("a", "b", a, a, 3); got != "" {
+		t.Errorf("got\n%s\nwant empty string", got)
+	}
+}
@@ -77,6 +77,28 @@ func Doc(args args.DocArgs) {
 	}
 }
 
+// Scan scans files for symbols without printing any documentation.
+// It is useful for commands other than doc, that need access to symbols.
+func Scan(args args.DocArgs) {
+	docArgs = args
+
+	ScanFiles()
+}
+
+// Lookup returns symbols matching the symbol path.
+// In contrary to Doc, it neither fails when no symbol is found nor
+// reports ambiguity. ScanFiles or Scan must be called before.
+func Lookup(path string) [][]sym.Symbol {
+	found := [][]sym.Symbol{}
+
+	for _, sp := range resolveSymbolPath(path) {
+		_, syms := findSymbol(sp)
+		found = append(found, syms...)
+	}
+
+	return found
+}
+
 func ScanFiles() {
 	var wg sync.WaitGroup
 	defer wg.Wait()
@@ -92,6 +92,7 @@ func scanEntityDeclaration(parent sym.Symbol, filepath string, name string, sCtx
 			filepath:  filepath,
 			key:       strings.ToLower(name),
 			name:      name,
+			lineNum:   sCtx.lineNum,
 			codeStart: sCtx.startIdx,
 		},
 	}
@@ -117,6 +118,7 @@ func scanPackageDeclaration(parent sym.Symbol, filepath string, name string, sCt
 		filepath:  filepath,
 		key:       strings.ToLower(name),
 		name:      name,
+		lineNum:   sCtx.lineNum,
 		codeStart: sCtx.startIdx,
 		Aliases:   map[sym.ID]sym.Symbol{},
 		Consts:    map[sym.ID]sym.Symbol{},
@@ -214,6 +216,7 @@ func scanPackageInstantiation(parent sym.Symbol, filepath string, name string, s
 			filepath:  filepath,
 			key:       strings.ToLower(name),
 			name:      name,
+			lineNum:   sCtx.lineNum,
 			codeStart: sCtx.startIdx,
 		},
 	}
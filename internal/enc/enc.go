@@ -2,6 +2,7 @@
 package enc
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -16,3 +17,35 @@ func OneHot(i int, width int) string {
 	}
 	return b.String()
 }
+
+func OneCold(i int, width int) string {
+	b := strings.Builder{}
+	for j := width - 1; j >= 0; j-- {
+		r := '1'
+		if j == i {
+			r = '0'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Gray returns i encoded in the binary reflected Gray code.
+func Gray(i int, width int) string {
+	return fmt.Sprintf("%0*b", width, i^(i>>1))
+}
+
+// Johnson returns i-th state of the Johnson counter.
+// The Johnson counter of given width has 2*width states.
+// For example, for width 3 the states are: 000, 001, 011, 111, 110, 100.
+func Johnson(i int, width int) string {
+	b := strings.Builder{}
+	for j := width - 1; j >= 0; j-- {
+		r := '0'
+		if (i <= width && j < i) || (i > width && j >= i-width) {
+			r = '1'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,28 @@
+package enc
+
+import (
+	"testing"
+)
+
+func TestEncodings(t *testing.T) {
+	var tests = []struct {
+		enc    func(int, int) string
+		name   string
+		width  int
+		values []string
+	}{
+		{OneHot, "one-hot", 3, []string{"001", "010", "100"}},
+		{OneCold, "one-cold", 3, []string{"110", "101", "011"}},
+		{Gray, "gray", 3, []string{"000", "001", "011", "010", "110", "111", "101", "100"}},
+		{Johnson, "johnson", 3, []string{"000", "001", "011", "111", "110", "100"}},
+		{Johnson, "johnson", 1, []string{"0", "1"}},
+	}
+
+	for _, test := range tests {
+		for i, want := range test.values {
+			if got := test.enc(i, test.width); got != want {
+				t.Errorf("%s(%d, %d): got %s; want %s", test.name, i, test.width, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package vhdl
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+var subprogramStart *regexp.Regexp = regexp.MustCompile(`(?i)^\s*((pure|impure)\s+)?(function|procedure)\b`)
+var isKeyword *regexp.Regexp = regexp.MustCompile(`(?i)\bis\b`)
+var isNewKeywords *regexp.Regexp = regexp.MustCompile(`(?i)\bis\s+new\b`)
+var beginLine *regexp.Regexp = regexp.MustCompile(`(?i)^\s*begin\b`)
+
+// archBeginTracker finds the 'begin' line of the architecture. The architecture
+// declarative region might contain subprogram bodies, which also have 'begin' lines.
+// Each subprogram body has exactly one 'begin', so it is enough to count bodies
+// which 'begin' was not yet found.
+type archBeginTracker struct {
+	inHeader      bool
+	parenDepth    int
+	pendingBodies int
+}
+
+// isArchBegin must be called for every line of the architecture declarative region,
+// starting from the line following the architecture declaration.
+func (t *archBeginTracker) isArchBegin(line []byte) bool {
+	if idx := bytes.Index(line, []byte("--")); idx >= 0 {
+		line = line[:idx]
+	}
+
+	if !t.inHeader && len(subprogramStart.FindIndex(line)) > 0 {
+		t.inHeader = true
+		t.parenDepth = 0
+	}
+
+	if t.inHeader {
+		// Only the part of the header outside parentheses determines
+		// whether it is a declaration or a body.
+		outer := []byte{}
+		for _, c := range line {
+			switch c {
+			case '(':
+				t.parenDepth += 1
+			case ')':
+				t.parenDepth -= 1
+			default:
+				if t.parenDepth == 0 {
+					outer = append(outer, c)
+				}
+			}
+		}
+
+		if len(isNewKeywords.FindIndex(outer)) > 0 || bytes.Contains(outer, []byte(";")) {
+			t.inHeader = false
+		} else if len(isKeyword.FindIndex(outer)) > 0 {
+			t.inHeader = false
+			t.pendingBodies += 1
+		}
+		return false
+	}
+
+	if len(beginLine.FindIndex(line)) > 0 {
+		if t.pendingBodies > 0 {
+			t.pendingBodies -= 1
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+// genArchitecture generates both declarations and definitions, as there is
+// no separate declarative part in the architecture.
+func genArchitecture(gens gen.Container, extraEmptyLines bool, b *strings.Builder) {
+	genRegion(
+		gens,
+		func(g gen.Generable) string { return g.GenDeclarations() + "\n" + g.GenDefinitions(gens) },
+		extraEmptyLines,
+		b,
+	)
+}
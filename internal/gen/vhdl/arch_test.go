@@ -0,0 +1,73 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestArchBeginTracker(t *testing.T) {
+	var tests = []struct {
+		code  string
+		begin uint // Line number of the architecture begin.
+	}{
+		{
+			code: `signal s : std_logic;
+begin
+end architecture;`,
+			begin: 2,
+		},
+		{
+			code: `function f(a : integer) return integer is
+begin
+   return a;
+end function;
+BEGIN -- Comment
+end architecture;`,
+			begin: 5,
+		},
+		{
+			code: `function f(
+   a : integer; -- is
+   b : integer
+) return integer;
+procedure p is
+   procedure nested is
+   begin
+   end procedure;
+begin
+   nested;
+end procedure;
+function g is new generic_g generic map (N => 1);
+begin
+end architecture;`,
+			begin: 13,
+		},
+		{
+			code: `impure function f
+   return integer
+is
+begin
+   return 0;
+end function;
+begin
+end architecture;`,
+			begin: 7,
+		},
+	}
+
+	for i, test := range tests {
+		tracker := archBeginTracker{}
+		sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader([]byte(test.code)))}
+		var begin uint
+		for sCtx.scan() {
+			if tracker.isArchBegin(sCtx.line) {
+				begin = sCtx.lineNum
+				break
+			}
+		}
+		if begin != test.begin {
+			t.Errorf("[%d]: got begin in line %d, want %d", i, begin, test.begin)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package vhdl
+
+import (
+	"fmt"
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+	"strings"
+)
+
+// array represents constrained array type.
+type array struct {
+	name  string
+	left  int
+	right int
+	elem  field
+	// lsbFirst is true if the left element is placed at the least significant bits.
+	lsbFirst bool
+}
+
+func (a *array) Name() string { return a.name }
+
+func (a *array) Width() int {
+	return a.length() * a.elem.width
+}
+
+func (a *array) length() int {
+	return rangeLength(a.left, a.right)
+}
+
+// indexes returns element indexes in the left to right order.
+func (a *array) indexes() []int {
+	idxs := []int{}
+	step := 1
+	if a.left > a.right {
+		step = -1
+	}
+	for i := a.left; ; i += step {
+		idxs = append(idxs, i)
+		if i == a.right {
+			break
+		}
+	}
+	return idxs
+}
+
+// slvIndexes returns element indexes in the order from the most significant bits.
+func (a *array) slvIndexes() []int {
+	idxs := a.indexes()
+	if a.lsbFirst {
+		for i, j := 0, len(idxs)-1; i < j; i, j = i+1, j-1 {
+			idxs[i], idxs[j] = idxs[j], idxs[i]
+		}
+	}
+	return idxs
+}
+
+func (a *array) GenDeclarations() string {
+	b := strings.Builder{}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function %s(slv : std_logic_vector(%d downto 0)) return %s;\n",
+			toTypeFuncName(a.name), a.Width()-1, a.name,
+		),
+	)
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_slv(%s : %s) return std_logic_vector;\n",
+			funcParamName(a.name), a.name,
+		),
+	)
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_str(%s : %s) return string;\n",
+			funcParamName(a.name), a.name,
+		),
+	)
+
+	return b.String()
+}
+
+func (a *array) GenDefinitions(gens gen.Container) string {
+	b := strings.Builder{}
+
+	a.genToArrayDefinition(gens, &b)
+	b.WriteRune('\n')
+	a.genToSlvDefinition(gens, &b)
+	b.WriteRune('\n')
+	a.genToStrDefinition(gens, &b)
+
+	return b.String()
+}
+
+func (a *array) genToArrayDefinition(gens gen.Container, b *strings.Builder) {
+	varName := funcParamName(a.name)
+	width := a.Width() - 1
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function %[1]s(slv : std_logic_vector(%[2]d downto 0)) return %[3]s is\n"+
+				"      variable %[4]s : %[3]s;\n"+
+				"   begin\n",
+			toTypeFuncName(a.name), width, a.name, varName,
+		),
+	)
+
+	for _, i := range a.slvIndexes() {
+		width = slvToTarget(a.elem, fmt.Sprintf("%s(%d)", varName, i), gens, b, width)
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"      return %s;\n"+
+				"   end function;\n",
+			varName,
+		),
+	)
+}
+
+func (a *array) genToSlvDefinition(gens gen.Container, b *strings.Builder) {
+	paramName := funcParamName(a.name)
+	width := a.Width() - 1
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_slv(%s : %s) return std_logic_vector is\n"+
+				"      variable slv : std_logic_vector(%d downto 0);\n"+
+				"   begin\n",
+			paramName, a.name, width,
+		),
+	)
+
+	for _, i := range a.slvIndexes() {
+		width = sourceToSlv(a.elem, fmt.Sprintf("%s(%d)", paramName, i), gens, b, width)
+	}
+
+	b.WriteString("      return slv;\n   end function;\n")
+}
+
+// genToStrDefinition generates to_str function. Elements are always in the left to right order.
+func (a *array) genToStrDefinition(gens gen.Container, b *strings.Builder) {
+	paramName := funcParamName(a.name)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_str(%s : %s) return string is\n"+
+				"   begin\n"+
+				"      return \"(\"",
+			paramName, a.name,
+		),
+	)
+
+	for n, i := range a.indexes() {
+		if n != 0 {
+			b.WriteString(" & \", \"")
+		}
+		b.WriteString(" & " + sourceToStr(a.elem, fmt.Sprintf("%s(%d)", paramName, i), gens))
+	}
+
+	b.WriteString(" & \")\";\n   end function;\n")
+}
+
+func (a *array) ParseArgs(args []string) error {
+	validParams := map[string]bool{
+		"order": true,
+	}
+
+	for _, arg := range args {
+		splits := strings.Split(arg, "=")
+		param := splits[0]
+
+		if _, ok := validParams[param]; !ok {
+			return fmt.Errorf("invalid parameter '%s'", param)
+		}
+
+		if len(splits) == 1 {
+			return fmt.Errorf("missing argument for '%s' parameter", param)
+		}
+		v := splits[1]
+
+		switch param {
+		case "order":
+			switch v {
+			case "msb-first":
+				a.lsbFirst = false
+			case "lsb-first":
+				a.lsbFirst = true
+			default:
+				return fmt.Errorf(
+					"invalid argument '%s' for 'order' parameter, "+
+						"valid arguments are: 'lsb-first' and 'msb-first' "+
+						"with 'msb-first' being the default one",
+					v,
+				)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+func TestArrayScanning(t *testing.T) {
+	var tests = []struct {
+		code       string
+		args       []string
+		width      int
+		slvIndexes []int
+		err        string
+	}{
+		{
+			code:       `type t_arr is array (0 to 3) of std_logic;`,
+			width:      4,
+			slvIndexes: []int{0, 1, 2, 3},
+		},
+		{
+			code:       `type t_arr is array (3 downto 1) of std_logic_vector(7 downto 0);`,
+			width:      24,
+			slvIndexes: []int{3, 2, 1},
+		},
+		{
+			code: `type t_arr is array (0 to 2)
+                      of t_enum; -- Comment`,
+			args:       []string{"order=lsb-first"},
+			width:      6,
+			slvIndexes: []int{2, 1, 0},
+		},
+		{
+			code: `type t_arr is array (natural range <>) of std_logic;`,
+			err:  "line 1: array 't_arr': unconstrained arrays are not supported",
+		},
+		{
+			code: `type t_arr is array (0 to 1) of t_unknown;`,
+			err:  "line 1: array 't_arr': element: unknown type 't_unknown'",
+		},
+		{
+			code: `type t_arr is array (0 to 1) of bit;`,
+			args: []string{"order=first"},
+			err: "line 1: array 't_arr': invalid argument 'first' for 'order' parameter, " +
+				"valid arguments are: 'lsb-first' and 'msb-first' with 'msb-first' being the default one",
+		},
+	}
+
+	gens := gen.Container{}
+	gens.Add(&enum{name: "t_enum", values: []string{"A", "B", "C"}, encoding: "sequential"})
+
+	for i, test := range tests {
+		sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader([]byte(test.code)))}
+		sCtx.scan()
+		array, err := scanArrayTypeDeclaration(&sCtx, gens, "t_arr", test.args)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		if array.Width() != test.width {
+			t.Errorf("[%d]: invalid width %d, want %d", i, array.Width(), test.width)
+		}
+		if !reflect.DeepEqual(array.slvIndexes(), test.slvIndexes) {
+			t.Errorf("[%d]: invalid slv indexes %v, want %v", i, array.slvIndexes(), test.slvIndexes)
+		}
+	}
+}
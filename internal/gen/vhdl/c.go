@@ -0,0 +1,243 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+// genCHeaders generates C header for each package with generables.
+// The header is named after the package.
+func genCHeaders(units []unit) error {
+	for _, u := range units {
+		if u.typ != "package" {
+			continue
+		}
+
+		content, err := genCHeader(u.name, u.gens)
+		if err != nil {
+			return fmt.Errorf("package %s: C header: %v", u.name, err)
+		}
+
+		path := filepath.Join(genArgs.CHeaderDir, strings.ToLower(u.name)+".h")
+		if err := emitFile(path, []byte(content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genCHeader(pkg string, gens gen.Container) (string, error) {
+	b := strings.Builder{}
+	guard := strings.ToUpper(pkg) + "_H"
+
+	b.WriteString(
+		fmt.Sprintf(
+			"/*\n"+
+				" * Below code was automatically generated with the thdl tool.\n"+
+				" * Do not modify it by hand, unless you really know what you do.\n"+
+				" * More info on https://github.com/m-kru/go-thdl.\n"+
+				" *\n"+
+				" * Source: VHDL package '%[1]s'.\n"+
+				" */\n\n"+
+				"#ifndef %[2]s\n"+
+				"#define %[2]s\n\n"+
+				"#include <stdint.h>\n",
+			pkg, guard,
+		),
+	)
+
+	for _, g := range gens {
+		b.WriteRune('\n')
+
+		var err error
+		switch g := g.(type) {
+		case *enum:
+			err = genCEnum(g, &b)
+		case *record:
+			genCRecord(g, &b)
+		case *array:
+			genCArray(g, &b)
+		default:
+			panic("should never happen")
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n#endif /* %s */\n", guard))
+
+	return b.String(), nil
+}
+
+// cName returns the C name of the type, the 't_' prefix is removed.
+func cName(typeName string) string {
+	return strings.TrimPrefix(strings.ToLower(typeName), "t_")
+}
+
+// cUintWidth returns the width of the smallest standard unsigned integer type
+// capable of holding width bits.
+func cUintWidth(width int) int {
+	for _, w := range []int{8, 16, 32} {
+		if width <= w {
+			return w
+		}
+	}
+	return 64
+}
+
+// cMask returns the mask of width bits starting from the shift bit.
+func cMask(width int, shift int, typeWidth int) string {
+	mask := (uint64(1)<<width - 1) << shift
+	return fmt.Sprintf("UINT%d_C(0x%0*x)", typeWidth, typeWidth/4, mask)
+}
+
+func genCEnum(e *enum, b *strings.Builder) error {
+	if e.Width() > 64 {
+		return fmt.Errorf("enumeration '%s': width %d exceeds 64 bits", e.name, e.Width())
+	}
+
+	prefix := strings.ToUpper(cName(e.name))
+
+	b.WriteString(
+		fmt.Sprintf(
+			"/* Enumeration type %s, %s encoding. */\n"+
+				"#define %s_WIDTH %d\n\n",
+			e.name, e.encoding, prefix, e.Width(),
+		),
+	)
+
+	// Values of C enumeration constants must be representable as int.
+	if e.Width() < 32 {
+		b.WriteString(fmt.Sprintf("enum %s {\n", cName(e.name)))
+		for i, v := range e.values {
+			val, _ := strconv.ParseUint(e.bits(i), 2, 64)
+			b.WriteString(fmt.Sprintf("\t%s_%s = 0x%x,\n", prefix, strings.ToUpper(v), val))
+		}
+		b.WriteString("};\n")
+	} else {
+		for i, v := range e.values {
+			val, _ := strconv.ParseUint(e.bits(i), 2, 64)
+			b.WriteString(fmt.Sprintf("#define %s_%s UINT64_C(0x%x)\n", prefix, strings.ToUpper(v), val))
+		}
+	}
+
+	return nil
+}
+
+func genCRecord(r *record, b *strings.Builder) {
+	name := cName(r.name)
+	prefix := strings.ToUpper(name)
+	ranges, width, _ := r.layout()
+
+	b.WriteString(
+		fmt.Sprintf(
+			"/* Record type %s. */\n"+
+				"#define %s_WIDTH %d\n",
+			r.name, prefix, width,
+		),
+	)
+
+	if width > 64 {
+		for i, f := range r.fields {
+			fPrefix := prefix + "_" + strings.ToUpper(f.name)
+			b.WriteString(fmt.Sprintf("#define %s_SHIFT %d\n", fPrefix, ranges[i].lo))
+			b.WriteString(fmt.Sprintf("#define %s_WIDTH %d\n", fPrefix, f.width))
+		}
+		b.WriteString("/* Record is wider than 64 bits, masks, pack and unpack functions are not generated. */\n")
+		return
+	}
+
+	wordWidth := cUintWidth(width)
+	wordType := fmt.Sprintf("uint%d_t", wordWidth)
+
+	for i, f := range r.fields {
+		fPrefix := prefix + "_" + strings.ToUpper(f.name)
+		b.WriteString(fmt.Sprintf("#define %s_SHIFT %d\n", fPrefix, ranges[i].lo))
+		b.WriteString(fmt.Sprintf("#define %s_WIDTH %d\n", fPrefix, f.width))
+		b.WriteString(fmt.Sprintf("#define %s_MASK %s\n", fPrefix, cMask(f.width, ranges[i].lo, wordWidth)))
+	}
+
+	// fieldTypes are C types of fields, integer fields are signed.
+	fieldTypes := make([]string, len(r.fields))
+	for i, f := range r.fields {
+		if f.typ == "integer" {
+			fieldTypes[i] = "int32_t"
+		} else {
+			fieldTypes[i] = fmt.Sprintf("uint%d_t", cUintWidth(f.width))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nstruct %s {\n", name))
+	for i, f := range r.fields {
+		b.WriteString(fmt.Sprintf("\t%s %s;\n", fieldTypes[i], strings.ToLower(f.name)))
+	}
+	b.WriteString("};\n")
+
+	paramName := funcParamName(r.name)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"\nstatic inline %[1]s %[2]s_pack(const struct %[2]s *%[3]s)\n"+
+				"{\n"+
+				"\t%[1]s word = 0;\n\n",
+			wordType, name, paramName,
+		),
+	)
+	for i, f := range r.fields {
+		value := fmt.Sprintf("%s->%s", paramName, strings.ToLower(f.name))
+		if fieldTypes[i] == "int32_t" {
+			// Prevent the sign extension.
+			value = "(uint32_t)" + value
+		}
+		fPrefix := prefix + "_" + strings.ToUpper(f.name)
+		b.WriteString(
+			fmt.Sprintf(
+				"\tword |= ((%s)%s << %s_SHIFT) & %s_MASK;\n",
+				wordType, value, fPrefix, fPrefix,
+			),
+		)
+	}
+	b.WriteString("\n\treturn word;\n}\n")
+
+	b.WriteString(
+		fmt.Sprintf(
+			"\nstatic inline void %[2]s_unpack(%[1]s word, struct %[2]s *%[3]s)\n"+
+				"{\n",
+			wordType, name, paramName,
+		),
+	)
+	for i, f := range r.fields {
+		cast := "(" + fieldTypes[i] + ")"
+		if fieldTypes[i] == "int32_t" {
+			cast += "(uint32_t)"
+		}
+		fPrefix := prefix + "_" + strings.ToUpper(f.name)
+		b.WriteString(
+			fmt.Sprintf(
+				"\t%s->%s = %s((word & %s_MASK) >> %s_SHIFT);\n",
+				paramName, strings.ToLower(f.name), cast, fPrefix, fPrefix,
+			),
+		)
+	}
+	b.WriteString("}\n")
+}
+
+func genCArray(a *array, b *strings.Builder) {
+	prefix := strings.ToUpper(cName(a.name))
+
+	b.WriteString(
+		fmt.Sprintf(
+			"/* Array type %s. */\n"+
+				"#define %[2]s_WIDTH %[3]d\n"+
+				"#define %[2]s_LENGTH %[4]d\n"+
+				"#define %[2]s_ELEMENT_WIDTH %[5]d\n",
+			a.name, prefix, a.Width(), a.length(), a.elem.width,
+		),
+	)
+}
@@ -0,0 +1,44 @@
+package vhdl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCEnum(t *testing.T) {
+	var tests = []struct {
+		enum enum
+		want string
+		err  string
+	}{
+		{
+			enum: enum{name: "t_op", values: []string{"Nop", "Load"}, encoding: "sequential"},
+			want: "enum op {\n\tOP_NOP = 0x0,\n\tOP_LOAD = 0x1,\n};\n",
+		},
+		{
+			enum: enum{name: "t_op", values: []string{"A", "B"}, encoding: "explicit", width: 40, explicitValues: []int64{0, 0x8000000000}},
+			want: "#define OP_A UINT64_C(0x0)\n#define OP_B UINT64_C(0x8000000000)\n",
+		},
+		{
+			enum: enum{name: "t_op", values: make([]string, 65), encoding: "one-hot"},
+			err:  "enumeration 't_op': width 65 exceeds 64 bits",
+		},
+	}
+
+	for i, test := range tests {
+		b := strings.Builder{}
+		err := genCEnum(&test.enum, &b)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		if !strings.HasSuffix(b.String(), test.want) {
+			t.Errorf("[%d]: got\n%s\nwant suffix\n%s", i, b.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package vhdl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/diff"
+	"github.com/m-kru/go-thdl/internal/vet/rprt"
+)
+
+// checkFile reports generated code modified by hand and stale generated code.
+// The file is not modified.
+func checkFile(filepath string, fileContent []byte) error {
+	for _, e := range FindEdits(fileContent) {
+		rprt.Report(filepath, e.Msg, e.LineNum, []byte(e.Line))
+	}
+
+	units, err := scanFile(fileContent)
+	if err != nil {
+		return err
+	}
+
+	if len(units) == 0 {
+		return nil
+	}
+
+	newContent, err := genNewFileContent(fileContent, units)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(fileContent, newContent) {
+		return nil
+	}
+
+	oldLines := splitLines(fileContent)
+	newLines := splitLines(newContent)
+
+	// Report the first line that differs.
+	lineNum := 0
+	for _, op := range diff.Lines(oldLines, newLines) {
+		if op.Kind != diff.Equal {
+			break
+		}
+		lineNum = op.A + 1
+	}
+	line := ""
+	if lineNum < len(oldLines) {
+		line = oldLines[lineNum]
+	}
+
+	msg := "stale generated code, run 'thdl gen'"
+	if stale := staleGenerables(fileContent, units); len(stale) > 0 {
+		msg += ", stale generables: " + strings.Join(stale, ", ")
+	}
+
+	rprt.ReportWithDetails(
+		filepath, msg, uint(lineNum+1), []byte(line),
+		diff.Unified(filepath, filepath+" (regenerated)", oldLines, newLines, 3),
+	)
+
+	return nil
+}
+
+// staleGenerables returns descriptions of generables which generated code
+// is not present in the file content.
+func staleGenerables(fileContent []byte, units []unit) []string {
+	stale := []string{}
+
+	for _, u := range units {
+		for _, g := range u.gens {
+			var code string
+			switch u.typ {
+			case "package":
+				code = g.GenDeclarations()
+			case "package body":
+				code = g.GenDefinitions(u.gens)
+			case "architecture":
+				code = g.GenDeclarations() + "\n" + g.GenDefinitions(u.gens)
+			default:
+				continue
+			}
+			if !bytes.Contains(fileContent, []byte(code)) {
+				stale = append(stale, fmt.Sprintf("%s in %s %s", g.Name(), u.typ, u.name))
+			}
+		}
+	}
+
+	return stale
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
@@ -0,0 +1,33 @@
+package vhdl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStaleGenerables(t *testing.T) {
+	units, err := scanFile([]byte(checksumTestCode))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	generated, err := genNewFileContent([]byte(checksumTestCode), units)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if stale := staleGenerables(generated, units); len(stale) != 0 {
+		t.Errorf("up to date code: got %v; want no stale generables", stale)
+	}
+
+	code := []byte(strings.Replace(string(generated), "(ONE, TWO)", "(ONE, TWO, THREE)", 1))
+	units, err = scanFile(code)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	want := []string{"t_state in package p", "t_state in package body p"}
+	if stale := staleGenerables(code, units); !reflect.DeepEqual(stale, want) {
+		t.Errorf("got %v; want %v", stale, want)
+	}
+}
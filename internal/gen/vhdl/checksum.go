@@ -0,0 +1,184 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/m-kru/go-thdl/internal/diff"
+)
+
+func checksum(region []byte) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE(region))
+}
+
+// region represents code located between the '--thdl:start' and '--thdl:end' lines.
+type region struct {
+	startLineNum uint
+	startLine    string
+	checksum     string // Checksum from the start line, empty for regions generated by older thdl versions.
+	lines        []string
+	endLine      string
+}
+
+func (r region) content() []byte {
+	b := bytes.Buffer{}
+	for _, l := range r.lines {
+		b.WriteString(l)
+		b.WriteRune('\n')
+	}
+	return b.Bytes()
+}
+
+func (r region) edited() bool {
+	return r.checksum != "" && checksum(r.content()) != r.checksum
+}
+
+// scanRegions returns all complete generated regions in the file content.
+func scanRegions(content []byte) []region {
+	regions := []region{}
+	var r *region
+
+	sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader(content))}
+	for sCtx.scan() {
+		if len(thdlStartLine.FindIndex(sCtx.line)) > 0 {
+			r = &region{startLineNum: sCtx.lineNum, startLine: string(sCtx.line)}
+			if sm := thdlStartChecksum.FindSubmatch(sCtx.line); len(sm) > 0 {
+				r.checksum = string(sm[1])
+			}
+		} else if r != nil && len(thdlEndLine.FindIndex(sCtx.line)) > 0 {
+			r.endLine = string(sCtx.line)
+			regions = append(regions, *r)
+			r = nil
+		} else if r != nil {
+			r.lines = append(r.lines, string(sCtx.line))
+		}
+	}
+
+	return regions
+}
+
+// Edit represents generated code modified by hand.
+type Edit struct {
+	LineNum uint
+	Line    string
+	Msg     string
+}
+
+// FindEdits returns generated regions, within the file content, modified by hand.
+// If the regenerated region matches the region checksum, then edits point to
+// the modified lines. Otherwise they point to the region start line.
+// Regions without checksum are not checked.
+func FindEdits(content []byte) []Edit {
+	edits := []Edit{}
+
+	regions := scanRegions(content)
+
+	// Regeneration is required only for pointing edited lines.
+	edited := false
+	for _, r := range regions {
+		if r.edited() {
+			edited = true
+			break
+		}
+	}
+	if !edited {
+		return edits
+	}
+
+	regenRegions, err := regenerateRegions(content)
+	if err != nil {
+		for _, r := range regions {
+			if r.edited() {
+				edits = append(edits, Edit{
+					LineNum: r.startLineNum,
+					Line:    r.startLine,
+					Msg:     fmt.Sprintf("generated code modified by hand, checksum mismatch, can't regenerate code: %v", err),
+				})
+			}
+		}
+		return edits
+	}
+
+	for i, r := range regions {
+		if !r.edited() {
+			continue
+		}
+
+		if len(regenRegions) == len(regions) && checksum(regenRegions[i].content()) == r.checksum {
+			edits = append(edits, r.findEdits(regenRegions[i])...)
+		} else {
+			edits = append(edits, Edit{
+				LineNum: r.startLineNum,
+				Line:    r.startLine,
+				Msg:     "generated code modified by hand, checksum mismatch",
+			})
+		}
+	}
+
+	return edits
+}
+
+// regenerateRegions returns generated regions of the regenerated file content.
+func regenerateRegions(content []byte) ([]region, error) {
+	// Edited file might use generables declared in other packages.
+	if err := lazyScanTree(); err != nil {
+		return nil, err
+	}
+
+	units, err := scanFile(content)
+	if err != nil {
+		return nil, err
+	}
+	newContent, err := genNewFileContent(content, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRegions(newContent), nil
+}
+
+// findEdits compares the region with the original generated region.
+func (r region) findEdits(orig region) []Edit {
+	edits := []Edit{}
+
+	// Line of region content with index i has number r.startLineNum + 1 + i.
+	lineNum := func(i int) uint { return r.startLineNum + 1 + uint(i) }
+	line := func(i int) string {
+		if i < len(r.lines) {
+			return r.lines[i]
+		}
+		return r.endLine
+	}
+
+	removedEdit := func(i int) Edit {
+		return Edit{LineNum: lineNum(i), Line: line(i), Msg: "generated code removed by hand before this line"}
+	}
+
+	// Index of the next line in the region, used to point lines removed by hand.
+	next := 0
+	removed := false
+	for _, op := range diff.Lines(orig.lines, r.lines) {
+		switch op.Kind {
+		case diff.Equal:
+			if removed {
+				edits = append(edits, removedEdit(next))
+				removed = false
+			}
+			next = op.B + 1
+		case diff.Delete:
+			removed = true
+		case diff.Insert:
+			// Modified line is reported as modified, not as removed and added.
+			removed = false
+			edits = append(edits, Edit{LineNum: lineNum(op.B), Line: line(op.B), Msg: "generated code modified by hand"})
+			next = op.B + 1
+		}
+	}
+	if removed {
+		edits = append(edits, removedEdit(next))
+	}
+
+	return edits
+}
@@ -0,0 +1,97 @@
+package vhdl
+
+import (
+	"strings"
+	"testing"
+)
+
+var checksumTestCode string = `package p is
+   --thdl:gen
+   type t_state is (ONE, TWO);
+end package;
+
+package body p is
+end package body;
+`
+
+func TestFindEdits(t *testing.T) {
+	units, err := scanFile([]byte(checksumTestCode))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	generated, err := genNewFileContent([]byte(checksumTestCode), units)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if edits := FindEdits(generated); len(edits) != 0 {
+		t.Fatalf("unmodified code: got %v; want no edits", edits)
+	}
+
+	var tests = []struct {
+		old     string
+		new     string
+		lineNum uint
+		msg     string
+	}{
+		{
+			old:     `return "ONE";`,
+			new:     `return "one";`,
+			lineNum: 45,
+			msg:     "generated code modified by hand",
+		},
+		{
+			old:     "         when ONE => return \"ONE\";\n",
+			new:     "",
+			lineNum: 45,
+			msg:     "generated code removed by hand before this line",
+		},
+		{
+			// Stale code, but not modified by hand.
+			old: "(ONE, TWO)",
+			new: "(ONE, TWO, THREE)",
+			msg: "",
+		},
+		{
+			old:     "end package;\n",
+			new:     "end package;\n   --thdl:start checksum=deadbeef\n   --thdl:end\n",
+			lineNum: 17,
+			msg:     "generated code modified by hand, checksum mismatch",
+		},
+	}
+
+	for i, test := range tests {
+		code := strings.Replace(string(generated), test.old, test.new, 1)
+		edits := FindEdits([]byte(code))
+
+		if test.msg == "" {
+			if len(edits) != 0 {
+				t.Errorf("[%d]: got %v; want no edits", i, edits)
+			}
+			continue
+		}
+
+		if len(edits) != 1 || edits[0].LineNum != test.lineNum || edits[0].Msg != test.msg {
+			t.Errorf("[%d]: got %v; want single edit (%d, %s)", i, edits, test.lineNum, test.msg)
+		}
+	}
+}
+
+func TestFindEditsUnscannable(t *testing.T) {
+	code := `package p is
+   --thdl:gen
+   type t_rec is record
+      a : integer range 0 to 7;
+   end record;
+end package;
+`
+	if edits := FindEdits([]byte(code)); len(edits) != 0 {
+		t.Errorf("code without generated regions: got %v; want no edits", edits)
+	}
+
+	code = strings.Replace(code, "end package;\n", "   --thdl:start checksum=deadbeef\n   --thdl:end\nend package;\n", 1)
+	edits := FindEdits([]byte(code))
+	if len(edits) != 1 || edits[0].LineNum != 6 || !strings.Contains(edits[0].Msg, "can't regenerate code") {
+		t.Errorf("got %v; want single edit reporting regeneration failure", edits)
+	}
+}
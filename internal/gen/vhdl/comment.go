@@ -1,7 +1,16 @@
 package vhdl
 
-var startCommentMsg string = `   --thdl:start
-   -- Below code was automatically generated with the thdl tool.
+import (
+	"fmt"
+)
+
+// startCommentMsg returns the start marker followed by the header comment.
+// The checksum is computed over all lines between the start and end markers.
+func startCommentMsg(checksum string) string {
+	return fmt.Sprintf("   --thdl:start checksum=%s\n", checksum)
+}
+
+var headerCommentMsg string = `   -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
 
@@ -0,0 +1,210 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/vhdl"
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
+
+var integerConstantDeclaration *regexp.Regexp = regexp.MustCompile(
+	`(?i)^\s*constant\s+(\w+)\s*:\s*(integer|natural|positive)\b[^:;]*:=\s*([^;]+);`,
+)
+var genericDeclaration *regexp.Regexp = regexp.MustCompile(
+	`(?i)^\s*(\w+)\s*:\s*(integer|natural|positive)\b[^:]*:=\s*(.+)$`,
+)
+var startsWithGeneric *regexp.Regexp = regexp.MustCompile(`(?i)^\s*generic\s*\(`)
+
+// constantDecl is integer constant, or package generic with default value, declared in a package.
+type constantDecl struct {
+	pkg  string
+	name string
+	expr string
+}
+
+// constants maps lowercase constant names to values.
+// Constants are stored under simple names and names qualified with the package name.
+type constants struct {
+	values map[string]int
+	// Simple names declared in multiple packages with different values.
+	ambiguous map[string]bool
+}
+
+func makeConstants() constants {
+	return constants{values: map[string]int{}, ambiguous: map[string]bool{}}
+}
+
+func (c constants) add(pkg string, name string, val int) {
+	pkg = strings.ToLower(pkg)
+	name = strings.ToLower(name)
+
+	c.values[pkg+"."+name] = val
+
+	if v, ok := c.values[name]; ok && v != val {
+		delete(c.values, name)
+		c.ambiguous[name] = true
+	} else if !c.ambiguous[name] {
+		c.values[name] = val
+	}
+}
+
+// get returns false as the second value if the constant is not found.
+func (c constants) get(name string) (int, bool, error) {
+	if c.ambiguous[name] {
+		return 0, false, fmt.Errorf(
+			"ambiguous identifier '%s', constant is declared in multiple packages, use selected name", name,
+		)
+	}
+	v, ok := c.values[name]
+	return v, ok, nil
+}
+
+// treeConstants are constants declared in all files within the working directory tree.
+var treeConstants constants = makeConstants()
+
+// scanTreeConstants scans integer constants declared in packages within the files.
+func scanTreeConstants(contents [][]byte) {
+	decls := []constantDecl{}
+	for _, content := range contents {
+		decls = append(decls, scanConstantDecls(content)...)
+	}
+
+	treeConstants = resolveConstants(decls, makeConstants())
+}
+
+// fileLookup returns function looking for constants declared in the file content.
+// If the constant is not declared in the file, then it is looked for in the tree constants.
+func fileLookup(content []byte) func(name string) (int, error) {
+	consts := resolveConstants(scanConstantDecls(content), treeConstants)
+
+	return func(name string) (int, error) {
+		for _, c := range []constants{consts, treeConstants} {
+			v, ok, err := c.get(name)
+			if err != nil {
+				return 0, err
+			} else if ok {
+				return v, nil
+			}
+		}
+		return 0, fmt.Errorf("unknown identifier '%s'", name)
+	}
+}
+
+// resolveConstants evaluates constant declarations. Declarations may reference each
+// other regardless of the order and may reference known constants. Declarations which
+// can't be evaluated, for example because of function calls, are skipped.
+func resolveConstants(decls []constantDecl, known constants) constants {
+	consts := makeConstants()
+
+	lookup := func(name string) (int, error) {
+		for _, c := range []constants{consts, known} {
+			v, ok, err := c.get(name)
+			if err != nil {
+				return 0, err
+			} else if ok {
+				return v, nil
+			}
+		}
+		return 0, fmt.Errorf("unknown identifier '%s'", name)
+	}
+
+	for progress := true; progress; {
+		progress = false
+		pending := []constantDecl{}
+		for _, d := range decls {
+			v, err := vhdl.EvalInt(d.expr, lookup)
+			if err != nil {
+				pending = append(pending, d)
+				continue
+			}
+			consts.add(d.pkg, d.name, v)
+			progress = true
+		}
+		decls = pending
+	}
+
+	return consts
+}
+
+// scanConstantDecls returns integer constants and package generics declared in packages.
+// Only single line constant declarations are supported.
+func scanConstantDecls(content []byte) []constantDecl {
+	decls := []constantDecl{}
+	pkg := ""
+	// Generic clause must start in the line following the package declaration.
+	expectGenerics := false
+
+	sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader(content))}
+	for sCtx.scan() {
+		sCtx.decomment()
+
+		if expectGenerics {
+			expectGenerics = false
+			if len(startsWithGeneric.FindIndex(sCtx.line)) > 0 {
+				decls = append(decls, scanPackageGenerics(&sCtx, pkg)...)
+				continue
+			}
+		}
+
+		if sm := re.PackageDeclaration.FindSubmatch(sCtx.line); len(sm) > 0 {
+			pkg = string(sm[1])
+			expectGenerics = true
+		} else if len(re.PackageBodyDeclaration.FindIndex(sCtx.line)) > 0 ||
+			len(re.ArchitectureDeclaration.FindIndex(sCtx.line)) > 0 ||
+			len(re.EndPackage.FindIndex(sCtx.line)) > 0 {
+			pkg = ""
+		} else if pkg == "" {
+			continue
+		} else if sm := integerConstantDeclaration.FindSubmatch(sCtx.line); len(sm) > 0 {
+			decls = append(decls, constantDecl{pkg: pkg, name: string(sm[1]), expr: string(sm[3])})
+		}
+	}
+
+	return decls
+}
+
+// scanPackageGenerics scans generic clause starting in the current line.
+func scanPackageGenerics(sCtx *scanContext, pkg string) []constantDecl {
+	decls := []constantDecl{}
+
+	// Collect the generic clause content between the parentheses.
+	clause := strings.Builder{}
+	depth := 0
+	done := false
+	for {
+		for _, r := range string(sCtx.line) {
+			if r == '(' {
+				depth += 1
+				if depth == 1 {
+					continue
+				}
+			} else if r == ')' {
+				depth -= 1
+				if depth == 0 {
+					done = true
+					break
+				}
+			}
+			if depth > 0 {
+				clause.WriteRune(r)
+			}
+		}
+		if done || !sCtx.scan() {
+			break
+		}
+		sCtx.decomment()
+		clause.WriteRune(' ')
+	}
+
+	for _, g := range strings.Split(clause.String(), ";") {
+		if sm := genericDeclaration.FindStringSubmatch(strings.TrimSpace(g)); len(sm) > 0 {
+			decls = append(decls, constantDecl{pkg: pkg, name: sm[1], expr: sm[3]})
+		}
+	}
+
+	return decls
+}
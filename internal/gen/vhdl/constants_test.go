@@ -0,0 +1,66 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestConstantsResolving(t *testing.T) {
+	code := []byte(`package a is
+   generic (
+      ADDR_W : natural := 2 ** 4; -- Comment
+      G_CLK  : std_logic := '0';
+      ID_W   : positive := BYTES
+   );
+   constant BYTES  : natural := DATA_W / 8;
+   constant DATA_W : natural := 32;
+   constant DEPTH  : natural := 8;
+   constant NAME   : string := "a";
+   constant LOG_W  : natural := log2(DATA_W);
+end package;
+
+package body a is
+   constant BODY_C : natural := 7;
+end package body;
+
+package b is
+   constant DEPTH : natural := 16;
+   constant BYTES : natural := 4;
+end package;
+`)
+
+	consts := resolveConstants(scanConstantDecls(code), makeConstants())
+
+	var tests = []struct {
+		name string
+		val  int
+		ok   bool
+		err  string
+	}{
+		{name: "addr_w", val: 16, ok: true},
+		{name: "id_w", val: 4, ok: true},
+		{name: "bytes", val: 4, ok: true},
+		{name: "data_w", val: 32, ok: true},
+		{name: "a.depth", val: 8, ok: true},
+		{name: "b.depth", val: 16, ok: true},
+		{name: "depth", err: "ambiguous identifier 'depth', constant is declared in multiple packages, use selected name"},
+		{name: "g_clk"},
+		{name: "name"},
+		{name: "log_w"},
+		{name: "body_c"},
+	}
+
+	for i, test := range tests {
+		val, ok, err := consts.get(test.name)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d]: %v", i, err)
+		} else if ok != test.ok || val != test.val {
+			t.Errorf("[%d]: got (%d, %t), want (%d, %t)", i, val, ok, test.val, test.ok)
+		}
+	}
+}
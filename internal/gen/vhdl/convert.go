@@ -0,0 +1,189 @@
+package vhdl
+
+import (
+	"fmt"
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+	"strings"
+)
+
+// Functions in this file generate conversions shared by record fields and array elements.
+// Target and source are VHDL names, for example 'rec.field' or 'arr(3)'.
+// Width is the index of the most significant bit of the part of the slv used for conversion.
+// Returned value is the index of the most significant bit of the next part.
+
+func slvToTarget(f field, target string, gens gen.Container, b *strings.Builder, width int) int {
+	typ := f.typ
+
+	switch typ {
+	case "std_logic", "std_ulogic":
+		b.WriteString(
+			fmt.Sprintf("      %s := slv(%d);\n", target, width),
+		)
+	case "bit", "boolean":
+		one := "'1'"
+		zero := "'0'"
+		if typ == "boolean" {
+			one = "true"
+			zero = "false"
+		}
+		b.WriteString(
+			fmt.Sprintf(
+				"      if slv(%[1]d) = '1' then\n"+
+					"         %[2]s := %[3]s;\n"+
+					"      elsif slv(%[1]d) = '0' then\n"+
+					"         %[2]s := %[4]s;\n"+
+					"      else\n"+
+					"         report \"bit %[1]d: cannot convert \" & to_string(slv(%[1]d)) & \" to %[5]s type\" severity failure;\n"+
+					"      end if;\n",
+				width, target, one, zero, typ,
+			),
+		)
+	case "integer":
+		b.WriteString(
+			fmt.Sprintf(
+				"      %s := to_integer(signed(slv(%d downto %d)));\n",
+				target, width, width-f.width+1,
+			),
+		)
+	case "natural", "positive":
+		b.WriteString(
+			fmt.Sprintf(
+				"      %s := to_integer(unsigned(slv(%d downto %d)));\n",
+				target, width, width-f.width+1,
+			),
+		)
+	case "std_logic_vector", "std_ulogic_vector":
+		b.WriteString(
+			fmt.Sprintf(
+				"      %s := slv(%d downto %d);\n",
+				target, width, width-f.width+1,
+			),
+		)
+	case "signed", "unsigned":
+		b.WriteString(
+			fmt.Sprintf(
+				"      %s := %s(slv(%d downto %d));\n",
+				target, typ, width, width-f.width+1,
+			),
+		)
+	default:
+		if g, ok := gens.Get(typ); ok {
+			b.WriteString(
+				fmt.Sprintf(
+					"      %s := %s(slv(%d downto %d));\n",
+					target, toTypeFuncName(g.Name()), width, width-f.width+1,
+				),
+			)
+		} else {
+			funcName := toTypeFuncName(typ)
+			if f.toType != "" {
+				funcName = f.toType
+			}
+			b.WriteString(
+				fmt.Sprintf(
+					"      %s := %s(slv(%d downto %d));\n",
+					target, funcName, width, width-f.width+1,
+				),
+			)
+		}
+	}
+
+	width -= f.width
+
+	return width
+}
+
+func sourceToSlv(f field, source string, gens gen.Container, b *strings.Builder, width int) int {
+	typ := f.typ
+
+	switch typ {
+	case "std_logic", "std_ulogic":
+		b.WriteString(
+			fmt.Sprintf("      slv(%d) := %s;\n", width, source),
+		)
+	case "bit":
+		b.WriteString(
+			fmt.Sprintf(
+				"      if %[1]s = '1' then slv(%[2]d) := '1'; else slv(%[2]d) := '0'; end if;\n",
+				source, width,
+			),
+		)
+	case "boolean":
+		b.WriteString(
+			fmt.Sprintf(
+				"      if %[1]s then slv(%[2]d) := '1'; else slv(%[2]d) := '0'; end if;\n",
+				source, width,
+			),
+		)
+	case "integer":
+		b.WriteString(
+			fmt.Sprintf(
+				"      slv(%d downto %d) := std_logic_vector(to_signed(%s, 32));\n",
+				width, width-f.width+1, source,
+			),
+		)
+	case "natural", "positive":
+		b.WriteString(
+			fmt.Sprintf(
+				"      slv(%d downto %d) := std_logic_vector(to_unsigned(%s, 32));\n",
+				width, width-f.width+1, source,
+			),
+		)
+	case "std_logic_vector", "std_ulogic_vector":
+		b.WriteString(
+			fmt.Sprintf(
+				"      slv(%d downto %d) := %s;\n",
+				width, width-f.width+1, source,
+			),
+		)
+	case "signed", "unsigned":
+		b.WriteString(
+			fmt.Sprintf(
+				"      slv(%d downto %d) := std_logic_vector(%s);\n",
+				width, width-f.width+1, source,
+			),
+		)
+	default:
+		if _, ok := gens.Get(typ); ok {
+			b.WriteString(
+				fmt.Sprintf(
+					"      slv(%d downto %d) := to_slv(%s);\n",
+					width, width-f.width+1, source,
+				),
+			)
+		} else {
+			funcName := "to_slv"
+			if f.toSlv != "" {
+				funcName = f.toSlv
+			}
+			b.WriteString(
+				fmt.Sprintf(
+					"      slv(%d downto %d) := %s(%s);\n",
+					width, width-f.width+1, funcName, source,
+				),
+			)
+		}
+	}
+
+	width -= f.width
+
+	return width
+}
+
+// sourceToStr returns expression converting source to string.
+func sourceToStr(f field, source string, gens gen.Container) string {
+	switch f.typ {
+	case "bit", "boolean", "std_logic", "std_ulogic", "std_logic_vector", "std_ulogic_vector", "integer", "natural", "positive", "signed", "unsigned":
+		return fmt.Sprintf("to_string(%s)", source)
+	default:
+		if _, ok := gens.Get(f.typ); ok {
+			return fmt.Sprintf("to_str(%s)", source)
+		} else {
+			toStr := f.toStr
+			if toStr == "" {
+				toStr = "to_str"
+			}
+			return fmt.Sprintf("%s(%s)", toStr, source)
+		}
+	}
+}
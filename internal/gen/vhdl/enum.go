@@ -5,6 +5,8 @@ import (
 	"github.com/m-kru/go-thdl/internal/enc"
 	"github.com/m-kru/go-thdl/internal/gen/gen"
 	"math"
+	"math/bits"
+	"strconv"
 	"strings"
 )
 
@@ -12,33 +14,95 @@ type enum struct {
 	name     string
 	values   []string
 	encoding string
+	// width is the width forced with the 'width' parameter, 0 if not set.
+	width int
+	// explicitValues are values of literals for the explicit encoding, -1 if not set.
+	explicitValues []int64
+
+	// Flags enabling generation of additional helpers.
+	count   bool
+	fromStr bool
+	isValid bool
+	succ    bool
+	toInt   bool
 }
 
 func (e *enum) Name() string { return e.name }
 
 func (e *enum) Width() int {
+	if e.width > 0 {
+		return e.width
+	}
+	return e.minWidth()
+}
+
+// minWidth returns the minimal width required to encode all values.
+func (e *enum) minWidth() int {
 	switch e.encoding {
-	case "one-hot":
+	case "one-hot", "one-cold":
 		return len(e.values)
-	case "gray":
-		panic("not yet implemented")
-	case "sequential":
-		return int(math.Ceil(math.Log2(float64(len(e.values)))))
+	case "johnson":
+		return (len(e.values) + 1) / 2
+	case "explicit":
+		max := int64(0)
+		for _, v := range e.explicitValues {
+			if v > max {
+				max = v
+			}
+		}
+		if max == 0 {
+			return 1
+		}
+		return bits.Len64(uint64(max))
 	default:
-		panic("should never happen")
+		// Gray and sequential encodings, other encodings are rejected by ParseArgs.
+		return int(math.Ceil(math.Log2(float64(len(e.values)))))
 	}
 }
 
 func (e *enum) GenDeclarations() string {
 	b := strings.Builder{}
 
+	if e.count {
+		b.WriteString(
+			fmt.Sprintf("   constant %s_COUNT : natural := %d;\n\n", constNamePrefix(e.name), len(e.values)),
+		)
+	}
+
 	e.genToEnumDeclaration(&b)
 	e.genToSlvDeclaration(&b)
 	e.genToStrDeclaration(&b)
 
+	paramName := funcParamName(e.name)
+	if e.fromStr {
+		b.WriteString(fmt.Sprintf("   function from_str(s : string) return %s;\n", e.name))
+	}
+	if e.isValid {
+		b.WriteString(
+			fmt.Sprintf(
+				"   function %s(slv : std_logic_vector(%d downto 0)) return boolean;\n",
+				isValidFuncName(e.name), e.Width()-1,
+			),
+		)
+	}
+	if e.succ {
+		b.WriteString(fmt.Sprintf("   function succ(%s : %s) return %[2]s;\n", paramName, e.name))
+		b.WriteString(fmt.Sprintf("   function pred(%s : %s) return %[2]s;\n", paramName, e.name))
+	}
+	if e.toInt {
+		b.WriteString(fmt.Sprintf("   function to_integer(%s : %s) return natural;\n", paramName, e.name))
+	}
+
 	return b.String()
 }
 
+// isValidFuncName returns the name of the function checking whether slv maps to any literal.
+// The type name is included in the function name, as enumerations of the same width
+// would have conflicting declarations.
+func isValidFuncName(typeName string) string {
+	return "is_valid_" + strings.TrimPrefix(toTypeFuncName(typeName), "to_")
+}
+
 func (e *enum) genToEnumDeclaration(b *strings.Builder) {
 	name := toTypeFuncName(e.name)
 	b.WriteString(
@@ -78,9 +142,110 @@ func (e *enum) GenDefinitions(gens gen.Container) string {
 	b.WriteRune('\n')
 	e.genToStrDefinition(&b)
 
+	if e.fromStr {
+		b.WriteRune('\n')
+		e.genFromStrDefinition(&b)
+	}
+	if e.isValid {
+		b.WriteRune('\n')
+		e.genIsValidDefinition(&b)
+	}
+	if e.succ {
+		b.WriteRune('\n')
+		e.genSuccPredDefinition("succ", &b)
+		b.WriteRune('\n')
+		e.genSuccPredDefinition("pred", &b)
+	}
+	if e.toInt {
+		b.WriteRune('\n')
+		e.genToIntegerDefinition(&b)
+	}
+
 	return b.String()
 }
 
+func (e *enum) genFromStrDefinition(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   function from_str(s : string) return %s is\n"+
+				"   begin\n",
+			e.name,
+		),
+	)
+	for i, v := range e.values {
+		keyword := "elsif"
+		if i == 0 {
+			keyword = "if"
+		}
+		b.WriteString(fmt.Sprintf("      %s s = \"%[2]s\" then return %[2]s;\n", keyword, v))
+	}
+	b.WriteString(
+		fmt.Sprintf(
+			"      end if;\n"+
+				"      report \"cannot convert '\" & s & \"' to %[1]s\" severity failure;\n"+
+				"      return %[1]s'left;\n"+
+				"   end function;\n",
+			e.name,
+		),
+	)
+}
+
+func (e *enum) genIsValidDefinition(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   function %s(slv : std_logic_vector(%d downto 0)) return boolean is\n"+
+				"   begin\n"+
+				"      case slv is\n",
+			isValidFuncName(e.name), e.Width()-1,
+		),
+	)
+
+	choices := []string{}
+	for i := range e.values {
+		choices = append(choices, e.slv(i))
+	}
+	b.WriteString(fmt.Sprintf("         when %s => return true;\n", strings.Join(choices, " | ")))
+
+	b.WriteString(
+		"         when others => return false;\n" +
+			"      end case;\n" +
+			"   end function;\n",
+	)
+}
+
+// genSuccPredDefinition generates wrap-around successor or predecessor function.
+func (e *enum) genSuccPredDefinition(fn string, b *strings.Builder) {
+	last, first := "right", "left"
+	if fn == "pred" {
+		last, first = "left", "right"
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function %[1]s(%[2]s : %[3]s) return %[3]s is\n"+
+				"   begin\n"+
+				"      if %[2]s = %[3]s'%[4]s then\n"+
+				"         return %[3]s'%[5]s;\n"+
+				"      end if;\n"+
+				"      return %[3]s'%[1]s(%[2]s);\n"+
+				"   end function;\n",
+			fn, funcParamName(e.name), e.name, last, first,
+		),
+	)
+}
+
+func (e *enum) genToIntegerDefinition(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_integer(%[1]s : %[2]s) return natural is\n"+
+				"   begin\n"+
+				"      return %[2]s'pos(%[1]s);\n"+
+				"   end function;\n",
+			funcParamName(e.name), e.name,
+		),
+	)
+}
+
 func (e *enum) genToEnumDefinition(b *strings.Builder) {
 	name := toTypeFuncName(e.name)
 	b.WriteString(
@@ -138,11 +303,14 @@ func (e *enum) genToStrDefinition(b *strings.Builder) {
 }
 
 func (e *enum) ParseArgs(args []string) error {
+	validFlags := map[string]bool{
+		"count": true, "from-str": true, "is-valid": true, "succ": true, "to-int": true,
+	}
 	validParams := map[string]bool{
-		"encoding": true,
+		"encoding": true, "width": true,
 	}
 	validEncodings := map[string]bool{
-		"gray": true, "one-hot": true, "sequential": true,
+		"explicit": true, "gray": true, "johnson": true, "one-cold": true, "one-hot": true, "sequential": true,
 	}
 
 	encoding := ""
@@ -151,7 +319,26 @@ func (e *enum) ParseArgs(args []string) error {
 		splits := strings.Split(arg, "=")
 		param := splits[0]
 
+		if len(splits) == 1 && validFlags[arg] {
+			switch arg {
+			case "count":
+				e.count = true
+			case "from-str":
+				e.fromStr = true
+			case "is-valid":
+				e.isValid = true
+			case "succ":
+				e.succ = true
+			case "to-int":
+				e.toInt = true
+			}
+			continue
+		}
+
 		if _, ok := validParams[param]; !ok {
+			if len(splits) == 1 {
+				return fmt.Errorf("invalid flag '%s'", arg)
+			}
 			return fmt.Errorf("invalid parameter '%s'", param)
 		}
 
@@ -165,12 +352,18 @@ func (e *enum) ParseArgs(args []string) error {
 			if _, ok := validEncodings[a]; !ok {
 				return fmt.Errorf(
 					"invalid argument '%s' for 'encoding' parameter, "+
-						"valid arguments are: 'gray', 'one-hot' and 'sequential' "+
+						"valid arguments are: 'explicit', 'gray', 'johnson', 'one-cold', 'one-hot' and 'sequential' "+
 						"with 'sequential' being the default one",
 					a,
 				)
 			}
 			encoding = a
+		case "width":
+			w, err := strconv.Atoi(a)
+			if err != nil || w <= 0 {
+				return fmt.Errorf("invalid argument '%s' for 'width' parameter, must be positive integer", a)
+			}
+			e.width = w
 		}
 	}
 
@@ -179,22 +372,87 @@ func (e *enum) ParseArgs(args []string) error {
 	}
 	e.encoding = encoding
 
+	if e.width > 0 && encoding != "explicit" && encoding != "gray" && encoding != "sequential" {
+		return fmt.Errorf("'width' parameter is not supported for '%s' encoding", encoding)
+	}
+
+	return nil
+}
+
+// parseLiteralArgs parses arguments provided for the literal with the '--thdl:' tag.
+func (e *enum) parseLiteralArgs(literal string, args string) error {
+	for _, arg := range strings.Fields(args) {
+		splits := strings.Split(arg, "=")
+		param := splits[0]
+
+		if param != "value" {
+			return fmt.Errorf("literal '%s': invalid parameter '%s'", literal, param)
+		}
+		if len(splits) == 1 {
+			return fmt.Errorf("literal '%s': missing argument for 'value' parameter", literal)
+		}
+		if e.encoding != "explicit" {
+			return fmt.Errorf("literal '%s': 'value' parameter requires 'explicit' encoding", literal)
+		}
+
+		v, err := strconv.ParseInt(splits[1], 0, 64)
+		if err != nil || v < 0 {
+			return fmt.Errorf(
+				"literal '%s': invalid argument '%s' for 'value' parameter, must be non-negative integer",
+				literal, splits[1],
+			)
+		}
+		e.explicitValues[len(e.explicitValues)-1] = v
+	}
+
+	return nil
+}
+
+// validate must be called after scanning all literals.
+func (e *enum) validate() error {
+	if e.encoding == "explicit" {
+		literals := map[int64]string{}
+		for i, v := range e.explicitValues {
+			if v < 0 {
+				return fmt.Errorf("missing value for literal '%s'", e.values[i])
+			}
+			if l, ok := literals[v]; ok {
+				return fmt.Errorf("literals '%s' and '%s' have the same value %d", l, e.values[i], v)
+			}
+			literals[v] = e.values[i]
+		}
+	}
+
+	if e.width > 0 && e.width < e.minWidth() {
+		return fmt.Errorf("width %d too small, at least %d bits are required", e.width, e.minWidth())
+	}
+
 	return nil
 }
 
 // slv returns std_logic_vector value for enum value of given index.
 func (e *enum) slv(idx int) string {
+	return "\"" + e.bits(idx) + "\""
+}
+
+// bits returns encoding of the value with given index as a string of bits.
+func (e *enum) bits(idx int) string {
 	var s string
 	switch e.encoding {
 	case "one-hot":
 		s = enc.OneHot(idx, e.Width())
+	case "one-cold":
+		s = enc.OneCold(idx, e.Width())
 	case "gray":
-		panic("not yet implemented")
-	case "sequential":
-		s = fmt.Sprintf("%0*b", e.Width(), idx)
+		s = enc.Gray(idx, e.Width())
+	case "johnson":
+		s = enc.Johnson(idx, e.Width())
+	case "explicit":
+		s = fmt.Sprintf("%0*b", e.Width(), e.explicitValues[idx])
 	default:
-		panic("should never happen")
+		// Sequential encoding, other encodings are rejected by ParseArgs.
+		s = fmt.Sprintf("%0*b", e.Width(), idx)
 	}
 
-	return "\"" + s + "\""
+	return s
 }
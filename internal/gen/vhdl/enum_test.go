@@ -66,3 +66,166 @@ func TestEnumScanning(t *testing.T) {
 		}
 	}
 }
+
+func TestEnumEncodings(t *testing.T) {
+	var tests = []struct {
+		encoding string
+		width    int
+		slvs     []string
+	}{
+		{encoding: "sequential", width: 3, slvs: []string{`"000"`, `"001"`, `"010"`, `"011"`, `"100"`}},
+		{encoding: "gray", width: 3, slvs: []string{`"000"`, `"001"`, `"011"`, `"010"`, `"110"`}},
+		{encoding: "johnson", width: 3, slvs: []string{`"000"`, `"001"`, `"011"`, `"111"`, `"110"`}},
+		{encoding: "one-hot", width: 5, slvs: []string{`"00001"`, `"00010"`, `"00100"`, `"01000"`, `"10000"`}},
+		{encoding: "one-cold", width: 5, slvs: []string{`"11110"`, `"11101"`, `"11011"`, `"10111"`, `"01111"`}},
+	}
+
+	for _, test := range tests {
+		e := enum{name: "t_state", values: []string{"A", "B", "C", "D", "E"}}
+		if err := e.ParseArgs([]string{"encoding=" + test.encoding}); err != nil {
+			t.Fatalf("%s: %v", test.encoding, err)
+		}
+		if e.Width() != test.width {
+			t.Errorf("%s: invalid width %d, want %d", test.encoding, e.Width(), test.width)
+		}
+		for i, want := range test.slvs {
+			if got := e.slv(i); got != want {
+				t.Errorf("%s: invalid slv for value %d, got %s, want %s", test.encoding, i, got, want)
+			}
+		}
+	}
+}
+
+func TestEnumExplicitEncoding(t *testing.T) {
+	var tests = []struct {
+		code  string
+		args  []string
+		width int
+		slvs  []string
+		err   string
+	}{
+		{
+			code: `type t_opcode is (
+                      NOP,   --thdl: value=0x00
+                      LOAD,  --thdl: value=0x1F
+                      STORE  --thdl: value=0b101
+                   );`,
+			args:  []string{"encoding=explicit"},
+			width: 5,
+			slvs:  []string{`"00000"`, `"11111"`, `"00101"`},
+		},
+		{
+			code: `type t_opcode is (
+                      NOP,  --thdl: value=0
+                      LOAD  --thdl: value=3
+                   );`,
+			args:  []string{"encoding=explicit", "width=8"},
+			width: 8,
+			slvs:  []string{`"00000000"`, `"00000011"`},
+		},
+		{
+			code:  `type t_opcode is (NOP, LOAD, STORE);`,
+			args:  []string{"width=4"},
+			width: 4,
+			slvs:  []string{`"0000"`, `"0001"`, `"0010"`},
+		},
+		{
+			code: `type t_opcode is (
+                      NOP,  --thdl: value=0
+                      LOAD
+                   );`,
+			args: []string{"encoding=explicit"},
+			err:  "line 1: enum 't_opcode': missing value for literal 'LOAD'",
+		},
+		{
+			code: `type t_opcode is (
+                      NOP,  --thdl: value=1
+                      LOAD  --thdl: value=1
+                   );`,
+			args: []string{"encoding=explicit"},
+			err:  "line 1: enum 't_opcode': literals 'NOP' and 'LOAD' have the same value 1",
+		},
+		{
+			code: `type t_opcode is (
+                      NOP,  --thdl: value=0
+                      LOAD  --thdl: value=8
+                   );`,
+			args: []string{"encoding=explicit", "width=3"},
+			err:  "line 1: enum 't_opcode': width 3 too small, at least 4 bits are required",
+		},
+		{
+			code: `type t_opcode is (
+                      NOP,  --thdl: value=0
+                      LOAD  --thdl: value=1
+                   );`,
+			args: []string{},
+			err:  "line 2: enum 't_opcode': literal 'NOP': 'value' parameter requires 'explicit' encoding",
+		},
+	}
+
+	for i, test := range tests {
+		sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader([]byte(test.code)))}
+		sCtx.scan()
+		enum, err := scanEnumTypeDeclaration(&sCtx, "t_opcode", test.args)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		if enum.Width() != test.width {
+			t.Errorf("[%d]: invalid width %d, want %d", i, enum.Width(), test.width)
+		}
+		for j, want := range test.slvs {
+			if got := enum.slv(j); got != want {
+				t.Errorf("[%d]: invalid slv for value %d, got %s, want %s", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestEnumHelperFlags(t *testing.T) {
+	var tests = []struct {
+		args []string
+		want enum
+		err  string
+	}{
+		{
+			args: []string{"count", "succ", "encoding=gray"},
+			want: enum{encoding: "gray", count: true, succ: true},
+		},
+		{
+			args: []string{"from-str", "is-valid", "to-int"},
+			want: enum{encoding: "sequential", fromStr: true, isValid: true, toInt: true},
+		},
+		{
+			args: []string{"next"},
+			err:  "invalid flag 'next'",
+		},
+		{
+			args: []string{"encoding"},
+			err:  "missing argument for 'encoding' parameter",
+		},
+	}
+
+	for i, test := range tests {
+		e := enum{}
+		err := e.ParseArgs(test.args)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d]: %v", i, err)
+		} else if e.encoding != test.want.encoding || e.count != test.want.count ||
+			e.fromStr != test.want.fromStr || e.isValid != test.want.isValid ||
+			e.succ != test.want.succ || e.toInt != test.want.toInt {
+			t.Errorf("[%d]: got %+v, want %+v", i, e, test.want)
+		}
+	}
+}
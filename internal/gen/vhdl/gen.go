@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/m-kru/go-thdl/internal/args"
+	"github.com/m-kru/go-thdl/internal/diff"
 	"github.com/m-kru/go-thdl/internal/gen/gen"
 	"github.com/m-kru/go-thdl/internal/utils"
 	"github.com/m-kru/go-thdl/internal/vhdl/re"
@@ -19,6 +20,9 @@ var genArgs args.GenArgs
 func Gen(args args.GenArgs, filepaths []string, wg *sync.WaitGroup) {
 	genArgs = args
 
+	// Constants and types used in record fields might be declared in any file in the tree.
+	ScanTree(append(utils.GetVHDLFilePaths(), filepaths...))
+
 	var filesWg sync.WaitGroup
 
 	for _, fp := range filepaths {
@@ -43,11 +47,28 @@ func processFile(filepath string, wg *sync.WaitGroup) {
 		log.Fatalf("reading %s: %v", filepath, err)
 	}
 
+	if genArgs.Check {
+		if err := checkFile(filepath, fileContent); err != nil {
+			log.Fatalf("%s: %v", filepath, err)
+		}
+		// Scanning errors are already reported by the checkFile.
+		units, _ := scanFile(fileContent)
+		if err := genOtherLanguages(units, filepath, fileContent); err != nil {
+			log.Fatalf("%s: %v", filepath, err)
+		}
+		return
+	}
+
 	units, err := scanFile(fileContent)
 	if err != nil {
 		log.Fatalf("%s: %v", filepath, err)
 	}
 
+	// Entities with ports of generated record types might be declared in files without generables.
+	if err := genOtherLanguages(units, filepath, fileContent); err != nil {
+		log.Fatalf("%s: %v", filepath, err)
+	}
+
 	if len(units) == 0 {
 		return
 	}
@@ -62,6 +83,13 @@ func processFile(filepath string, wg *sync.WaitGroup) {
 		return
 	}
 
+	if genArgs.Diff {
+		fmt.Print(diff.Unified(
+			"a/"+filepath, "b/"+filepath, splitLines(fileContent), splitLines(newContent), 3,
+		))
+		return
+	}
+
 	// We can assume that the file already exists so the perm is discarded anyway.
 	err = os.WriteFile(filepath, newContent, 0)
 	if err != nil {
@@ -69,6 +97,46 @@ func processFile(filepath string, wg *sync.WaitGroup) {
 	}
 }
 
+// genOtherLanguages generates files for other languages requested with flags,
+// and register map files requested with the regmap record flag.
+func genOtherLanguages(units []unit, filepath string, fileContent []byte) error {
+	if err := genRegmaps(units, filepath); err != nil {
+		return err
+	}
+
+	if genArgs.CHeaderDir != "" {
+		if err := genCHeaders(units); err != nil {
+			return err
+		}
+	}
+
+	if genArgs.PythonDir != "" {
+		if err := genPythonModules(units); err != nil {
+			return err
+		}
+	}
+
+	if genArgs.SVDir != "" {
+		if err := genSVPackages(units); err != nil {
+			return err
+		}
+	}
+
+	if genArgs.TestbenchDir != "" {
+		if err := genTestbenches(units); err != nil {
+			return err
+		}
+	}
+
+	if genArgs.WrapperDir != "" {
+		if err := genWrappers(fileContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func genNewFileContent(fileContent []byte, units []unit) ([]byte, error) {
 	sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader(fileContent))}
 	b := strings.Builder{}
@@ -95,6 +163,7 @@ func genNewFileContent(fileContent []byte, units []unit) ([]byte, error) {
 func genDesignUnit(u unit, sCtx *scanContext, b *strings.Builder) error {
 	inUnit := false
 	gotoThdlEnd := false
+	archTracker := archBeginTracker{}
 	for {
 		if !sCtx.scan() {
 			if gotoThdlEnd {
@@ -112,10 +181,23 @@ func genDesignUnit(u unit, sCtx *scanContext, b *strings.Builder) error {
 
 		if sCtx.lineNum == u.lineNum {
 			inUnit = true
+			b.Write(sCtx.line)
+			b.WriteRune('\n')
+			continue
 		}
 
 		if inUnit {
 			if u.typ == "architecture" {
+				if len(thdlStartLine.FindIndex(sCtx.line)) > 0 {
+					genArchitecture(u.gens, false, b)
+					gotoThdlEnd = true
+					continue
+				} else if archTracker.isArchBegin(sCtx.line) {
+					genArchitecture(u.gens, true, b)
+					b.Write(sCtx.line)
+					b.WriteRune('\n')
+					break
+				}
 			} else if u.typ == "package" {
 				if len(thdlStartLine.FindIndex(sCtx.line)) > 0 {
 					genPackage(u.gens, false, false, b)
@@ -141,7 +223,7 @@ func genDesignUnit(u unit, sCtx *scanContext, b *strings.Builder) error {
 					break
 				}
 			} else {
-				panic("should never happen")
+				return fmt.Errorf("unknown design unit type '%s'", u.typ)
 			}
 		}
 
@@ -154,21 +236,30 @@ func genDesignUnit(u unit, sCtx *scanContext, b *strings.Builder) error {
 
 // body is false for package and true for package body.
 func genPackage(gens gen.Container, body bool, extraEmptyLines bool, b *strings.Builder) {
-	if extraEmptyLines {
+	code := func(g gen.Generable) string { return g.GenDeclarations() }
+	if body {
+		code = func(g gen.Generable) string { return g.GenDefinitions(gens) }
+	}
+	genRegion(gens, code, extraEmptyLines, b)
+}
+
+// genRegion generates region between '--thdl:start' and '--thdl:end' lines.
+// Code returns the code generated for single generable.
+func genRegion(gens gen.Container, code func(g gen.Generable) string, extraEmptyLines bool, b *strings.Builder) {
+	// Do not duplicate the empty line preceding the region.
+	if extraEmptyLines && !strings.HasSuffix(b.String(), "\n\n") {
 		b.WriteRune('\n')
 	}
 
-	b.WriteString(startCommentMsg)
+	region := strings.Builder{}
+	region.WriteString(headerCommentMsg)
 	for _, g := range gens {
-		var s string
-		if body {
-			s = g.GenDefinitions(gens)
-		} else {
-			s = g.GenDeclarations()
-		}
-		b.WriteString(s)
-		b.WriteRune('\n')
+		region.WriteString(code(g))
+		region.WriteRune('\n')
 	}
+
+	b.WriteString(startCommentMsg(checksum([]byte(region.String()))))
+	b.WriteString(region.String())
 	b.WriteString(endCommentMsg)
 
 	if extraEmptyLines {
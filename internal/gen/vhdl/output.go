@@ -0,0 +1,60 @@
+package vhdl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/m-kru/go-thdl/internal/diff"
+	"github.com/m-kru/go-thdl/internal/vet/rprt"
+)
+
+// emitFile handles file generated for other language, for example C header.
+// Depending on the gen mode the file is written, printed, diffed or checked.
+func emitFile(path string, content []byte) error {
+	oldContent, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %v", path, err)
+	}
+
+	if genArgs.Check {
+		if bytes.Equal(oldContent, content) {
+			return nil
+		}
+		oldLines := splitLines(oldContent)
+		line := ""
+		if len(oldLines) > 0 {
+			line = oldLines[0]
+		}
+		rprt.ReportWithDetails(
+			path, "stale generated file, run 'thdl gen'", 1, []byte(line),
+			diff.Unified(path, path+" (regenerated)", oldLines, splitLines(content), 3),
+		)
+		return nil
+	}
+
+	if genArgs.ToStdout {
+		fmt.Printf("%s", string(content))
+		return nil
+	}
+
+	if genArgs.Diff {
+		fmt.Print(diff.Unified("a/"+path, "b/"+path, splitLines(oldContent), splitLines(content), 3))
+		return nil
+	}
+
+	if bytes.Equal(oldContent, content) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory for %s: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("writing file %s: %v", path, err)
+	}
+
+	return nil
+}
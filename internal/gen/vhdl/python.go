@@ -0,0 +1,273 @@
+package vhdl
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+// genPythonModules generates Python module for each package with generables.
+// The module is named after the package.
+func genPythonModules(units []unit) error {
+	for _, u := range units {
+		if u.typ != "package" {
+			continue
+		}
+
+		content := genPythonModule(u.name, u.gens)
+
+		path := filepath.Join(genArgs.PythonDir, strings.ToLower(u.name)+".py")
+		if err := emitFile(path, []byte(content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genPythonModule(pkg string, gens gen.Container) string {
+	b := strings.Builder{}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"# Below code was automatically generated with the thdl tool.\n"+
+				"# Do not modify it by hand, unless you really know what you do.\n"+
+				"# More info on https://github.com/m-kru/go-thdl.\n"+
+				"#\n"+
+				"# Source: VHDL package '%s'.\n",
+			pkg,
+		),
+	)
+
+	// Import only what is used.
+	hasEnum, hasRecord, hasNestedRecord, hasInteger := false, false, false, false
+	// Classes of field types declared in other packages, grouped by modules.
+	modules := []string{}
+	classes := map[string][]string{}
+	imported := map[string]bool{}
+	for _, g := range gens {
+		switch g := g.(type) {
+		case *enum:
+			hasEnum = true
+		case *record:
+			hasRecord = true
+			for _, f := range g.fields {
+				fg := pythonFieldGenerable(f, gens)
+				if f.typ == "integer" {
+					hasInteger = true
+				} else if _, ok := fg.(*record); ok {
+					hasNestedRecord = true
+				}
+
+				if fg == nil {
+					continue
+				}
+				mod := strings.ToLower(generablePackage(fg, pkg, gens))
+				if mod == strings.ToLower(pkg) {
+					continue
+				}
+				class := pythonClassName(fg.Name())
+				if imported[mod+"."+class] {
+					continue
+				}
+				imported[mod+"."+class] = true
+				if _, ok := classes[mod]; !ok {
+					modules = append(modules, mod)
+				}
+				classes[mod] = append(classes[mod], class)
+			}
+		}
+	}
+
+	imports := []string{}
+	if hasRecord {
+		if hasNestedRecord {
+			imports = append(imports, "from dataclasses import dataclass, field")
+		} else {
+			imports = append(imports, "from dataclasses import dataclass")
+		}
+	}
+	if hasEnum {
+		imports = append(imports, "from enum import IntEnum")
+	}
+	if len(imports) > 0 {
+		b.WriteString("\n" + strings.Join(imports, "\n") + "\n")
+	}
+	if len(modules) > 0 {
+		b.WriteString("\n")
+		for _, mod := range modules {
+			b.WriteString(fmt.Sprintf("from %s import %s\n", mod, strings.Join(classes[mod], ", ")))
+		}
+	}
+
+	if hasInteger {
+		b.WriteString(
+			"\n\ndef _to_signed(val: int, width: int) -> int:\n" +
+				"    if val >> (width - 1):\n" +
+				"        return val - (1 << width)\n" +
+				"    return val\n",
+		)
+	}
+
+	for _, g := range gens {
+		b.WriteString("\n\n")
+
+		switch g := g.(type) {
+		case *enum:
+			genPythonEnum(g, &b)
+		case *record:
+			genPythonRecord(g, gens, &b)
+		case *array:
+			genPythonArray(g, &b)
+		default:
+			panic("should never happen")
+		}
+	}
+
+	return b.String()
+}
+
+// pythonClassName returns the Python class name of the type, for example 'StatusArr' for 't_status_arr'.
+func pythonClassName(typeName string) string {
+	name := ""
+	for _, s := range strings.Split(cName(typeName), "_") {
+		if s != "" {
+			name += strings.ToUpper(s[0:1]) + s[1:]
+		}
+	}
+	return name
+}
+
+func genPythonEnum(e *enum, b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"%s_WIDTH = %d\n\n\n"+
+				"class %s(IntEnum):\n"+
+				"    \"\"\"Enumeration type %s, %s encoding.\"\"\"\n\n",
+			strings.ToUpper(cName(e.name)), e.Width(), pythonClassName(e.name), e.name, e.encoding,
+		),
+	)
+
+	for i, v := range e.values {
+		// Python integers are not limited, so one-hot encodings wider than 64 bits are also fine.
+		val, _ := new(big.Int).SetString(e.bits(i), 2)
+		b.WriteString(fmt.Sprintf("    %s = 0x%s\n", strings.ToUpper(v), val.Text(16)))
+	}
+}
+
+func genPythonRecord(r *record, gens gen.Container, b *strings.Builder) {
+	className := pythonClassName(r.name)
+	ranges, width, _ := r.layout()
+
+	b.WriteString(
+		fmt.Sprintf(
+			"%s_WIDTH = %d\n\n\n"+
+				"@dataclass\n"+
+				"class %s:\n"+
+				"    \"\"\"Record type %s.\"\"\"\n\n",
+			strings.ToUpper(cName(r.name)), width, className, r.name,
+		),
+	)
+
+	for _, f := range r.fields {
+		name := strings.ToLower(f.name)
+		switch g := pythonFieldGenerable(f, gens).(type) {
+		case *enum:
+			b.WriteString(
+				fmt.Sprintf("    %s: %[2]s = %[2]s.%s\n", name, pythonClassName(g.name), strings.ToUpper(g.values[0])),
+			)
+		case *record:
+			b.WriteString(
+				fmt.Sprintf("    %s: %[2]s = field(default_factory=%[2]s)\n", name, pythonClassName(g.name)),
+			)
+		default:
+			if f.typ == "boolean" {
+				b.WriteString(fmt.Sprintf("    %s: bool = False\n", name))
+			} else {
+				b.WriteString(fmt.Sprintf("    %s: int = 0\n", name))
+			}
+		}
+	}
+
+	b.WriteString(
+		"\n    def to_int(self) -> int:\n" +
+			"        \"\"\"Returns the value packed the same way as by the VHDL to_slv function.\"\"\"\n" +
+			"        val = 0\n",
+	)
+	for i, f := range r.fields {
+		value := "int(self." + strings.ToLower(f.name) + ")"
+		if _, ok := pythonFieldGenerable(f, gens).(*record); ok {
+			value = "self." + strings.ToLower(f.name) + ".to_int()"
+		}
+		b.WriteString(
+			fmt.Sprintf("        val |= (%s & 0x%s) << %d\n", value, pythonMask(f.width), ranges[i].lo),
+		)
+	}
+	b.WriteString("        return val\n")
+
+	b.WriteString(
+		fmt.Sprintf(
+			"\n    @classmethod\n"+
+				"    def from_int(cls, val: int) -> \"%s\":\n"+
+				"        \"\"\"Returns the record unpacked the same way as by the VHDL %s function.\"\"\"\n"+
+				"        return cls(\n",
+			className, toTypeFuncName(r.name),
+		),
+	)
+	for i, f := range r.fields {
+		value := fmt.Sprintf("(val >> %d) & 0x%s", ranges[i].lo, pythonMask(f.width))
+		switch g := pythonFieldGenerable(f, gens).(type) {
+		case *enum:
+			value = fmt.Sprintf("%s(%s)", pythonClassName(g.name), value)
+		case *record:
+			value = fmt.Sprintf("%s.from_int(%s)", pythonClassName(g.name), value)
+		default:
+			if f.typ == "boolean" {
+				value = fmt.Sprintf("bool(%s)", value)
+			} else if f.typ == "integer" {
+				value = fmt.Sprintf("_to_signed(%s, %d)", value, f.width)
+			}
+		}
+		b.WriteString(fmt.Sprintf("            %s=%s,\n", strings.ToLower(f.name), value))
+	}
+	b.WriteString("        )\n")
+}
+
+// pythonFieldGenerable returns the generable of the field type, nil if the field type is not generable.
+// The generable might be declared in other package.
+func pythonFieldGenerable(f field, gens gen.Container) gen.Generable {
+	if f.typGen != nil {
+		return f.typGen
+	}
+	if g, ok := gens.Get(f.typ); ok {
+		return g
+	}
+	return nil
+}
+
+// pythonMask returns hexadecimal digits of the mask of width bits.
+// Python integers are not limited, so the width is not limited either.
+func pythonMask(width int) string {
+	mask := strings.Repeat("f", width/4)
+	if width%4 != 0 {
+		mask = fmt.Sprintf("%x", (1<<(width%4))-1) + mask
+	}
+	return mask
+}
+
+func genPythonArray(a *array, b *strings.Builder) {
+	prefix := strings.ToUpper(cName(a.name))
+
+	b.WriteString(
+		fmt.Sprintf(
+			"# Array type %s.\n"+
+				"%[2]s_WIDTH = %[3]d\n"+
+				"%[2]s_LENGTH = %[4]d\n"+
+				"%[2]s_ELEMENT_WIDTH = %[5]d\n",
+			a.name, prefix, a.Width(), a.length(), a.elem.width,
+		),
+	)
+}
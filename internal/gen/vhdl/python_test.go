@@ -0,0 +1,36 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestPythonNames(t *testing.T) {
+	var tests = []struct {
+		typ  string
+		name string
+	}{
+		{"t_status", "Status"},
+		{"t_status_arr", "StatusArr"},
+		{"T_Rx_Data", "RxData"},
+		{"beat", "Beat"},
+	}
+
+	for i, test := range tests {
+		if name := pythonClassName(test.typ); name != test.name {
+			t.Errorf("[%d]: got %s, want %s", i, name, test.name)
+		}
+	}
+
+	var masks = []struct {
+		width int
+		mask  string
+	}{
+		{1, "1"}, {3, "7"}, {8, "ff"}, {11, "7ff"}, {65, "1ffffffffffffffff"},
+	}
+
+	for i, test := range masks {
+		if mask := pythonMask(test.width); mask != test.mask {
+			t.Errorf("[%d]: got mask %s, want %s", i, mask, test.mask)
+		}
+	}
+}
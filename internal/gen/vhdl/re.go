@@ -6,4 +6,5 @@ var thdlGenLine *re.Regexp = re.MustCompile(`\s*--thdl:gen\b`)
 var thdlFieldArgs *re.Regexp = re.MustCompile(`--thdl: `)
 
 var thdlStartLine *re.Regexp = re.MustCompile(`\s*--thdl:start\b`)
+var thdlStartChecksum *re.Regexp = re.MustCompile(`--thdl:start\s+checksum=([0-9a-f]+)`)
 var thdlEndLine *re.Regexp = re.MustCompile(`\s*--thdl:end\b`)
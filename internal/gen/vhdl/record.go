@@ -3,6 +3,7 @@ package vhdl
 import (
 	"fmt"
 	"github.com/m-kru/go-thdl/internal/gen/gen"
+	"strconv"
 	"strings"
 )
 
@@ -13,36 +14,325 @@ type field struct {
 	toType string
 	toSlv  string
 	toStr  string
+	// Number of unused bits following the field in the packing order.
+	pad int
+	// Index of the least significant bit of the field, -1 if not set.
+	offset int
+	// Generable of the field type, nil if the type is not generable.
+	typGen gen.Generable
+	// Register map access, address offset and reset value.
+	// Empty access means rw, address -1 means address following previous register.
+	access string
+	addr   int64
+	reset  uint64
 }
 
 type record struct {
 	name    string
 	fields  []field
 	noToStr bool
+	// Flags enabling generation of init constant, masked conversion and equality helpers.
+	init   bool
+	masked bool
+	equal  bool
+	// Serialization unit, "bytes" or "words", empty if serialization is not generated.
+	serialize string
+	bigEndian bool
+	// lsbFirst is true if the first field is placed at the least significant bits.
+	lsbFirst bool
+	// Width of the std_logic_vector is padded to the multiple of align bits.
+	align int
+	// Register map is generated if regmap is true.
+	regmap bool
+	bus    string
+	base   uint64
+}
+
+// bitRange is the range of std_logic_vector bits occupied by the field.
+type bitRange struct {
+	hi int
+	lo int
 }
 
 func (r *record) Name() string { return r.name }
 
 func (r *record) Width() int {
+	_, width, _ := r.layout()
+	return width
+}
+
+// layout returns bit ranges of fields and the width of the std_logic_vector.
+// Fields are placed starting from the least significant bits, so in the case of
+// msb-first order they are placed in the reversed declaration order.
+func (r *record) layout() ([]bitRange, int, error) {
+	ranges := make([]bitRange, len(r.fields))
+
+	idxs := []int{}
+	for i := range r.fields {
+		if r.lsbFirst {
+			idxs = append(idxs, i)
+		} else {
+			idxs = append([]int{i}, idxs...)
+		}
+	}
+
+	width := 0
+	for _, i := range idxs {
+		f := r.fields[i]
+		if !r.lsbFirst {
+			width += f.pad
+		}
+		if f.offset >= 0 {
+			if f.offset < width {
+				return nil, 0, fmt.Errorf(
+					"field '%s': offset %d overlaps other field or padding, lowest free bit is %d",
+					f.name, f.offset, width,
+				)
+			}
+			width = f.offset
+		}
+		ranges[i] = bitRange{hi: width + f.width - 1, lo: width}
+		width += f.width
+		if r.lsbFirst {
+			width += f.pad
+		}
+	}
+
+	if r.align > 0 && width%r.align != 0 {
+		width += r.align - width%r.align
+	}
+
+	return ranges, width, nil
+}
+
+// hasUnusedBits returns true if some bits of the std_logic_vector are not occupied by fields.
+func (r *record) hasUnusedBits() bool {
 	width := 0
 	for _, f := range r.fields {
 		width += f.width
 	}
-	return width
+	return width != r.Width()
+}
+
+func (r *record) validate() error {
+	_, _, err := r.layout()
+	if err != nil {
+		return err
+	}
+
+	if r.regmap {
+		return r.validateRegmap()
+	}
+	for _, f := range r.fields {
+		if f.access != "" || f.addr >= 0 || f.reset != 0 {
+			return fmt.Errorf("field '%s': access, addr and reset arguments require 'regmap' record flag", f.name)
+		}
+	}
+
+	return nil
 }
 
 func (r *record) GenDeclarations() string {
 	b := strings.Builder{}
 
+	r.genConstantsDeclaration(&b)
+	b.WriteRune('\n')
+	if r.regmap {
+		r.genRegmapConstantsDeclaration(&b)
+		b.WriteRune('\n')
+	}
+	if r.init || r.masked {
+		r.genInitDeclaration(&b)
+		b.WriteRune('\n')
+	}
+	if r.masked || r.equal {
+		r.genMaskTypeDeclaration(&b)
+		b.WriteRune('\n')
+	}
+	if r.serialize != "" {
+		r.genSerialTypeDeclaration(&b)
+		b.WriteRune('\n')
+	}
 	r.genToRecordDeclaration(&b)
 	r.genToSlvDeclaration(&b)
 	if !r.noToStr {
 		r.genToStrDeclaration(&b)
 	}
+	if r.masked {
+		b.WriteString(
+			fmt.Sprintf(
+				"   function %s_masked(%s : %s; mask : %s) return %[3]s;\n",
+				toTypeFuncName(r.name), funcParamName(r.name), r.name, r.maskTypeName(),
+			),
+		)
+	}
+	if r.equal {
+		b.WriteString(
+			fmt.Sprintf("   function equal(a, b : %s; mask : %s) return boolean;\n", r.name, r.maskTypeName()),
+		)
+	}
+	if r.serialize != "" {
+		b.WriteString(
+			fmt.Sprintf(
+				"   function to_%[1]s(%[2]s : %[3]s) return %[4]s;\n"+
+					"   function from_%[1]s(%[1]s : %[4]s) return %[3]s;\n",
+				r.serialize, funcParamName(r.name), r.name, r.serialTypeName(),
+			),
+		)
+	}
 
 	return b.String()
 }
 
+func (r *record) serialTypeName() string { return r.name + "_" + r.serialize }
+
+// serialUnitWidth returns the width of the serialization unit, byte or 32-bit word.
+func (r *record) serialUnitWidth() int {
+	if r.serialize == "words" {
+		return 32
+	}
+	return 8
+}
+
+// serialLength returns the number of units required to hold the record.
+func (r *record) serialLength() int {
+	w := r.serialUnitWidth()
+	return (r.Width() + w - 1) / w
+}
+
+func (r *record) genSerialTypeDeclaration(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   type %s is array (0 to %d) of std_logic_vector(%d downto 0);\n",
+			r.serialTypeName(), r.serialLength()-1, r.serialUnitWidth()-1,
+		),
+	)
+}
+
+// serialSlice returns the slice of the serialized std_logic_vector holding the unit with index i.
+// In the big endian order the unit with index 0 holds the most significant bits.
+func (r *record) serialSlice() string {
+	w := r.serialUnitWidth()
+	idx := "i"
+	if r.bigEndian {
+		idx = fmt.Sprintf("(%d - i)", r.serialLength()-1)
+	}
+	return fmt.Sprintf("slv(%[1]d * %[2]s + %[3]d downto %[1]d * %[2]s)", w, idx, w-1)
+}
+
+// genSerialDefinitions generates functions serializing record to array of units and back.
+// The record is placed at the least significant bits, the remaining bits are zeros.
+func (r *record) genSerialDefinitions(b *strings.Builder) {
+	paramName := funcParamName(r.name)
+	width := r.Width()
+	serialWidth := r.serialLength() * r.serialUnitWidth()
+
+	init := ""
+	if serialWidth > width {
+		init = " := (others => '0')"
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function to_%[1]s(%[2]s : %[3]s) return %[4]s is\n"+
+				"      variable slv : std_logic_vector(%[5]d downto 0)%[6]s;\n"+
+				"      variable %[1]s : %[4]s;\n"+
+				"   begin\n"+
+				"      slv(%[7]d downto 0) := to_slv(%[2]s);\n"+
+				"      for i in %[1]s'range loop\n"+
+				"         %[1]s(i) := %[8]s;\n"+
+				"      end loop;\n"+
+				"      return %[1]s;\n"+
+				"   end function;\n\n",
+			r.serialize, paramName, r.name, r.serialTypeName(), serialWidth-1, init, width-1, r.serialSlice(),
+		),
+	)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function from_%[1]s(%[1]s : %[2]s) return %[3]s is\n"+
+				"      variable slv : std_logic_vector(%[4]d downto 0);\n"+
+				"   begin\n"+
+				"      for i in %[1]s'range loop\n"+
+				"         %[5]s := %[1]s(i);\n"+
+				"      end loop;\n"+
+				"      return %[6]s(slv(%[7]d downto 0));\n"+
+				"   end function;\n",
+			r.serialize, r.serialTypeName(), r.name, serialWidth-1, r.serialSlice(), toTypeFuncName(r.name), width-1,
+		),
+	)
+}
+
+func (r *record) maskTypeName() string { return r.name + "_mask" }
+
+func (r *record) genInitDeclaration(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf("   constant %s_INIT : %s := %s;\n", constNamePrefix(r.name), r.name, r.initValue()),
+	)
+}
+
+// initValue returns aggregate with default values of all fields.
+// Function calls are avoided for types generated in the same scope, as the constant
+// is declared before function bodies.
+func (r *record) initValue() string {
+	vals := []string{}
+	for _, f := range r.fields {
+		vals = append(vals, fmt.Sprintf("%s => %s", f.name, fieldInitValue(f)))
+	}
+	return "(" + strings.Join(vals, ", ") + ")"
+}
+
+func fieldInitValue(f field) string {
+	switch f.typ {
+	case "std_logic", "std_ulogic", "bit":
+		return "'0'"
+	case "boolean":
+		return "false"
+	case "integer", "natural":
+		return "0"
+	case "positive":
+		return "1"
+	case "std_logic_vector", "std_ulogic_vector", "signed", "unsigned":
+		return "(others => '0')"
+	}
+
+	switch g := f.typGen.(type) {
+	case *enum:
+		return g.name + "'left"
+	case *record:
+		return g.initValue()
+	case *array:
+		return "(others => " + fieldInitValue(g.elem) + ")"
+	}
+
+	// Foreign type, the conversion function is declared in other package.
+	funcName := toTypeFuncName(f.typ)
+	if f.toType != "" {
+		funcName = f.toType
+	}
+	return fmt.Sprintf("%s((%d downto 0 => '0'))", funcName, f.width-1)
+}
+
+func (r *record) genMaskTypeDeclaration(b *strings.Builder) {
+	b.WriteString(fmt.Sprintf("   type %s is record\n", r.maskTypeName()))
+	for _, f := range r.fields {
+		b.WriteString(fmt.Sprintf("      %s : boolean;\n", f.name))
+	}
+	b.WriteString("   end record;\n")
+}
+
+func (r *record) genConstantsDeclaration(b *strings.Builder) {
+	prefix := constNamePrefix(r.name)
+	ranges, width, _ := r.layout()
+
+	b.WriteString(fmt.Sprintf("   constant %s_WIDTH : natural := %d;\n", prefix, width))
+	for i, f := range r.fields {
+		name := prefix + "_" + strings.ToUpper(f.name)
+		b.WriteString(fmt.Sprintf("   constant %s_HI : natural := %d;\n", name, ranges[i].hi))
+		b.WriteString(fmt.Sprintf("   constant %s_LO : natural := %d;\n", name, ranges[i].lo))
+	}
+}
+
 func (r *record) genToRecordDeclaration(b *strings.Builder) {
 	funcName := toTypeFuncName(r.name)
 	b.WriteString(
@@ -83,14 +373,61 @@ func (r *record) GenDefinitions(gens gen.Container) string {
 		b.WriteRune('\n')
 		r.genToStrDefinition(gens, &b)
 	}
+	if r.masked {
+		b.WriteRune('\n')
+		r.genToMaskedDefinition(&b)
+	}
+	if r.equal {
+		b.WriteRune('\n')
+		r.genEqualDefinition(&b)
+	}
+	if r.serialize != "" {
+		b.WriteRune('\n')
+		r.genSerialDefinitions(&b)
+	}
 
 	return b.String()
 }
 
+// genToMaskedDefinition generates function returning record with fields not selected
+// by the mask set to the init values.
+func (r *record) genToMaskedDefinition(b *strings.Builder) {
+	paramName := funcParamName(r.name)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   function %s_masked(%s : %s; mask : %s) return %[3]s is\n"+
+				"      variable masked : %[3]s := %[5]s_INIT;\n"+
+				"   begin\n",
+			toTypeFuncName(r.name), paramName, r.name, r.maskTypeName(), constNamePrefix(r.name),
+		),
+	)
+	for _, f := range r.fields {
+		b.WriteString(fmt.Sprintf("      if mask.%[1]s then masked.%[1]s := %[2]s.%[1]s; end if;\n", f.name, paramName))
+	}
+	b.WriteString("      return masked;\n   end function;\n")
+}
+
+// genEqualDefinition generates function comparing only fields selected by the mask.
+func (r *record) genEqualDefinition(b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   function equal(a, b : %s; mask : %s) return boolean is\n"+
+				"   begin\n",
+			r.name, r.maskTypeName(),
+		),
+	)
+	for _, f := range r.fields {
+		b.WriteString(fmt.Sprintf("      if mask.%[1]s and a.%[1]s /= b.%[1]s then return false; end if;\n", f.name))
+	}
+	b.WriteString("      return true;\n   end function;\n")
+}
+
 func (r *record) genToRecordDefinition(gens gen.Container, b *strings.Builder) {
 	funcName := toTypeFuncName(r.name)
 	varName := funcParamName(r.name)
-	width := r.Width() - 1
+	ranges, width, _ := r.layout()
+	width -= 1
 
 	b.WriteString(
 		fmt.Sprintf(
@@ -102,7 +439,7 @@ func (r *record) genToRecordDefinition(gens gen.Container, b *strings.Builder) {
 	)
 
 	for i, _ := range r.fields {
-		width = r.slvToField(i, gens, b, width)
+		r.slvToField(i, gens, b, ranges[i].hi)
 	}
 
 	b.WriteString(
@@ -116,207 +453,153 @@ func (r *record) genToRecordDefinition(gens gen.Container, b *strings.Builder) {
 
 func (r *record) genToSlvDefinition(gens gen.Container, b *strings.Builder) {
 	paramName := funcParamName(r.name)
-	width := r.Width() - 1
+	ranges, width, _ := r.layout()
+	width -= 1
+
+	init := ""
+	if r.hasUnusedBits() {
+		init = " := (others => '0')"
+	}
 
 	b.WriteString(
 		fmt.Sprintf(
 			"   function to_slv(%s : %s) return std_logic_vector is\n"+
-				"      variable slv : std_logic_vector(%d downto 0);\n"+
+				"      variable slv : std_logic_vector(%d downto 0)%s;\n"+
 				"   begin\n",
-			paramName, r.name, width,
+			paramName, r.name, width, init,
 		),
 	)
 
 	for i, _ := range r.fields {
-		width = r.fieldToSlv(i, gens, b, width)
+		r.fieldToSlv(i, gens, b, ranges[i].hi)
 	}
 
 	b.WriteString("      return slv;\n   end function;\n")
 }
 
 func (r *record) slvToField(idx int, gens gen.Container, b *strings.Builder, width int) int {
-	varName := funcParamName(r.name)
-
 	f := r.fields[idx]
-	typ := f.typ
-
-	switch typ {
-	case "std_logic", "std_ulogic":
-		b.WriteString(
-			fmt.Sprintf("      %s.%s := slv(%d);\n", varName, f.name, width),
-		)
-	case "bit", "boolean":
-		one := "'1'"
-		zero := "'0'"
-		if typ == "boolean" {
-			one = "true"
-			zero = "false"
-		}
-		b.WriteString(
-			fmt.Sprintf(
-				"      if slv(%[1]d) = '1' then\n"+
-					"         %[2]s.%[3]s := %[4]s;\n"+
-					"      elsif slv(%[1]d) = '0' then\n"+
-					"         %[2]s.%[3]s := %[5]s;\n"+
-					"      else\n"+
-					"         report \"bit %[1]d: cannot convert \" & to_string(slv(%[1]d)) & \" to %[6]s type\" severity failure;\n"+
-					"      end if;\n",
-				width, varName, f.name, one, zero, typ,
-			),
-		)
-	case "integer":
-		b.WriteString(
-			fmt.Sprintf(
-				"      %s.%s := to_integer(signed(slv(%d downto %d)));\n",
-				varName, f.name, width, width-f.width+1,
-			),
-		)
-	case "natural", "positive":
-		b.WriteString(
-			fmt.Sprintf(
-				"      %s.%s := to_integer(unsigned(slv(%d downto %d)));\n",
-				varName, f.name, width, width-f.width+1,
-			),
-		)
-	case "std_logic_vector", "std_ulogic_vector":
-		b.WriteString(
-			fmt.Sprintf(
-				"      %s.%s := slv(%d downto %d);\n",
-				varName, f.name, width, width-f.width+1,
-			),
-		)
-	case "signed", "unsigned":
-		b.WriteString(
-			fmt.Sprintf(
-				"      %s.%s := %s(slv(%d downto %d));\n",
-				varName, f.name, typ, width, width-f.width+1,
-			),
-		)
-	default:
-		if g, ok := gens.Get(typ); ok {
-			b.WriteString(
-				fmt.Sprintf(
-					"      %s.%s := %s(slv(%d downto %d));\n",
-					varName, f.name, toTypeFuncName(g.Name()), width, width-f.width+1,
-				),
-			)
-		} else if f.width != 0 {
-			funcName := toTypeFuncName(typ)
-			if f.toType != "" {
-				funcName = f.toType
-			}
-			b.WriteString(
-				fmt.Sprintf(
-					"      %s.%s := %s(slv(%d downto %d));\n",
-					varName, f.name, funcName, width, width-f.width+1,
-				),
-			)
-		} else {
-			panic("should never happen")
-		}
-	}
-
-	width -= f.width
-
-	return width
+	return slvToTarget(f, funcParamName(r.name)+"."+f.name, gens, b, width)
 }
 
 func (r *record) fieldToSlv(idx int, gens gen.Container, b *strings.Builder, width int) int {
-	varName := funcParamName(r.name)
-
 	f := r.fields[idx]
-	typ := f.typ
-
-	switch typ {
-	case "std_logic", "std_ulogic":
-		b.WriteString(
-			fmt.Sprintf("      slv(%d) := %s.%s;\n", width, varName, f.name),
-		)
-	case "bit":
-		b.WriteString(
-			fmt.Sprintf(
-				"      if %[1]s.%[2]s = '1' then slv(%[3]d) := '1'; else slv(%[3]d) := '0'; end if;\n",
-				varName, f.name, width,
-			),
-		)
-	case "boolean":
-		b.WriteString(
-			fmt.Sprintf(
-				"      if %[1]s.%[2]s then slv(%[3]d) := '1'; else slv(%[3]d) := '0'; end if;\n",
-				varName, f.name, width,
-			),
-		)
-	case "integer":
-		b.WriteString(
-			fmt.Sprintf(
-				"      slv(%d downto %d) := std_logic_vector(to_signed(%s.%s, 32));\n",
-				width, width-f.width+1, varName, f.name,
-			),
-		)
-	case "natural", "positive":
-		b.WriteString(
-			fmt.Sprintf(
-				"      slv(%d downto %d) := std_logic_vector(to_unsigned(%s.%s, 32));\n",
-				width, width-f.width+1, varName, f.name,
-			),
-		)
-	case "std_logic_vector", "std_ulogic_vector":
-		b.WriteString(
-			fmt.Sprintf(
-				"      slv(%d downto %d) := %s.%s;\n",
-				width, width-f.width+1, varName, f.name,
-			),
-		)
-	case "signed", "unsigned":
-		b.WriteString(
-			fmt.Sprintf(
-				"      slv(%d downto %d) := std_logic_vector(%s.%s);\n",
-				width, width-f.width+1, varName, f.name,
-			),
-		)
-	default:
-		if _, ok := gens.Get(typ); ok {
-			b.WriteString(
-				fmt.Sprintf(
-					"      slv(%d downto %d) := to_slv(%s.%s);\n",
-					width, width-f.width+1, varName, f.name,
-				),
-			)
-		} else if f.width != 0 {
-			funcName := "to_slv"
-			if f.toSlv != "" {
-				funcName = f.toSlv
-			}
-			b.WriteString(
-				fmt.Sprintf(
-					"      slv(%d downto %d) := %s(%s.%s);\n",
-					width, width-f.width+1, funcName, varName, f.name,
-				),
-			)
-		} else {
-			panic("should never happen")
-		}
-	}
-
-	width -= f.width
-
-	return width
+	return sourceToSlv(f, funcParamName(r.name)+"."+f.name, gens, b, width)
 }
 
 func (r *record) ParseArgs(args []string) error {
 	validFlags := map[string]bool{
-		"no-to-str": true,
+		"equal": true, "init": true, "masked": true, "no-to-str": true, "regmap": true,
 	}
+	validParams := map[string]bool{
+		"align": true, "base": true, "bus": true, "endian": true, "order": true, "serialize": true,
+	}
+	endianSet := false
 
 	for _, arg := range args {
-		if _, ok := validFlags[arg]; !ok {
-			return fmt.Errorf("invalid flag '%s'", arg)
+		splits := strings.Split(arg, "=")
+		param := splits[0]
+
+		if len(splits) == 1 {
+			if _, ok := validParams[param]; ok {
+				return fmt.Errorf("missing argument for '%s' parameter", param)
+			} else if _, ok := validFlags[arg]; !ok {
+				return fmt.Errorf("invalid flag '%s'", arg)
+			}
+
+			switch arg {
+			case "equal":
+				r.equal = true
+			case "init":
+				r.init = true
+			case "masked":
+				r.masked = true
+			case "no-to-str":
+				r.noToStr = true
+			case "regmap":
+				r.regmap = true
+			}
+			continue
 		}
 
-		switch arg {
-		case "no-to-str":
-			r.noToStr = true
+		if _, ok := validParams[param]; !ok {
+			return fmt.Errorf("invalid parameter '%s'", param)
 		}
+		v := splits[1]
+
+		switch param {
+		case "align":
+			a, err := strconv.Atoi(v)
+			if err != nil || a <= 0 {
+				return fmt.Errorf("invalid argument '%s' for 'align' parameter, must be positive integer", v)
+			}
+			r.align = a
+		case "order":
+			switch v {
+			case "msb-first":
+				r.lsbFirst = false
+			case "lsb-first":
+				r.lsbFirst = true
+			default:
+				return fmt.Errorf(
+					"invalid argument '%s' for 'order' parameter, "+
+						"valid arguments are: 'lsb-first' and 'msb-first' "+
+						"with 'msb-first' being the default one",
+					v,
+				)
+			}
+		case "serialize":
+			if v != "bytes" && v != "words" {
+				return fmt.Errorf(
+					"invalid argument '%s' for 'serialize' parameter, valid arguments are: 'bytes' and 'words'", v,
+				)
+			}
+			r.serialize = v
+		case "endian":
+			switch v {
+			case "big":
+				r.bigEndian = true
+			case "little":
+				r.bigEndian = false
+			default:
+				return fmt.Errorf(
+					"invalid argument '%s' for 'endian' parameter, "+
+						"valid arguments are: 'big' and 'little' "+
+						"with 'big' being the default one",
+					v,
+				)
+			}
+			endianSet = true
+		case "bus":
+			if v != "axi4lite" && v != "wishbone" {
+				return fmt.Errorf(
+					"invalid argument '%s' for 'bus' parameter, valid arguments are: 'axi4lite' and 'wishbone'", v,
+				)
+			}
+			r.bus = v
+		case "base":
+			b, err := strconv.ParseUint(v, 0, 32)
+			if err != nil || b%4 != 0 {
+				return fmt.Errorf(
+					"invalid argument '%s' for 'base' parameter, must be 32-bit unsigned integer aligned to 4 bytes", v,
+				)
+			}
+			r.base = b
+		}
+	}
+
+	if !r.regmap && (r.bus != "" || r.base != 0) {
+		return fmt.Errorf("'bus' and 'base' parameters require 'regmap' flag")
+	} else if r.regmap && r.bus == "" {
+		r.bus = "axi4lite"
+	}
+
+	if r.serialize == "" {
+		if endianSet {
+			return fmt.Errorf("'endian' parameter requires 'serialize' parameter")
+		}
+	} else if !endianSet {
+		r.bigEndian = true
 	}
 
 	return nil
@@ -346,10 +629,7 @@ func (r *record) genToStrDefinition(gens gen.Container, b *strings.Builder) {
 }
 
 func (r *record) fieldToStr(idx int, gens gen.Container, withName bool, b *strings.Builder) {
-	paramName := funcParamName(r.name)
-
 	f := r.fields[idx]
-	typ := f.typ
 
 	if idx != 0 {
 		b.WriteString(" & \", \" &")
@@ -359,20 +639,5 @@ func (r *record) fieldToStr(idx int, gens gen.Container, withName bool, b *strin
 		b.WriteString(fmt.Sprintf(" \"%s => \" &", f.name))
 	}
 
-	switch typ {
-	case "bit", "boolean", "std_logic", "std_ulogic", "std_logic_vector", "integer", "natural", "positive", "signed", "unsigned":
-		b.WriteString(fmt.Sprintf(" to_string(%s.%s)", paramName, f.name))
-	default:
-		if _, ok := gens.Get(typ); ok {
-			b.WriteString(fmt.Sprintf(" to_str(%s.%s)", paramName, f.name))
-		} else if f.width != 0 {
-			toStr := f.toStr
-			if toStr == "" {
-				toStr = "to_str"
-			}
-			b.WriteString(fmt.Sprintf(" %s(%s.%s)", f.toStr, paramName, f.name))
-		} else {
-			panic("should never happen")
-		}
-	}
+	b.WriteString(" " + sourceToStr(f, funcParamName(r.name)+"."+f.name, gens))
 }
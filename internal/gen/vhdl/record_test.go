@@ -0,0 +1,175 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+func TestRecordLayout(t *testing.T) {
+	var tests = []struct {
+		code   string
+		args   []string
+		width  int
+		ranges []bitRange
+		err    string
+	}{
+		{
+			code: `type t_rec is record
+                      a : std_logic_vector(3 downto 0);
+                      b : std_logic;
+                   end record;`,
+			width:  5,
+			ranges: []bitRange{{4, 1}, {0, 0}},
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic_vector(3 downto 0);
+                      b : std_logic;
+                   end record;`,
+			args:   []string{"order=lsb-first"},
+			width:  5,
+			ranges: []bitRange{{3, 0}, {4, 4}},
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic_vector(3 downto 0); --thdl: pad=2
+                      b : std_logic;
+                   end record;`,
+			args:   []string{"align=8"},
+			width:  8,
+			ranges: []bitRange{{6, 3}, {0, 0}},
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic; --thdl: pad=1
+                      b : t_foreign; --thdl: width=4 offset=8
+                      c : bit;
+                   end record;`,
+			args:   []string{"order=lsb-first", "align=16"},
+			width:  16,
+			ranges: []bitRange{{0, 0}, {11, 8}, {12, 12}},
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic; --thdl: offset=4
+                      b : std_logic_vector(7 downto 0);
+                   end record;`,
+			err: "line 1: record 't_rec': field 'a': offset 4 overlaps other field or padding, lowest free bit is 8",
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic; --thdl: pad=-1
+                   end record;`,
+			err: "line 2: record 't_rec': field 'a': value for 'pad' parameter must not be negative, current value -1",
+		},
+		{
+			code: `type t_rec is record
+                      a : std_logic;
+                   end record;`,
+			args: []string{"align=0"},
+			err:  "line 1: record 't_rec': invalid argument '0' for 'align' parameter, must be positive integer",
+		},
+		{
+			code: `type t_rec is record
+                      a : integer range 0 to 7;
+                   end record;`,
+			err: "line 2: record 't_rec': field 'a': integer types with range constraint are not yet supported",
+		},
+		{
+			code: `type t_rec is record
+                      a : t_foreign; --thdl: width=0
+                   end record;`,
+			err: "line 2: record 't_rec': field 'a': value for 'width' parameter must be positive, current value 0",
+		},
+	}
+
+	gens := gen.Container{}
+
+	for i, test := range tests {
+		sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader([]byte(test.code)))}
+		sCtx.scan()
+		rec, err := scanRecordTypeDeclaration(&sCtx, gens, "t_rec", test.args)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		ranges, width, _ := rec.layout()
+		if width != test.width {
+			t.Errorf("[%d]: invalid width %d, want %d", i, width, test.width)
+		}
+		if !reflect.DeepEqual(ranges, test.ranges) {
+			t.Errorf("[%d]: invalid ranges %v, want %v", i, ranges, test.ranges)
+		}
+	}
+}
+
+func TestRecordInitValue(t *testing.T) {
+	e := &enum{name: "t_state", values: []string{"IDLE", "BUSY"}}
+	inner := &record{name: "t_inner", fields: []field{{name: "s", typ: "t_state", width: 1, typGen: e}}}
+
+	var tests = []struct {
+		f    field
+		want string
+	}{
+		{field{typ: "std_logic", width: 1}, "'0'"},
+		{field{typ: "boolean", width: 1}, "false"},
+		{field{typ: "positive", width: 32}, "1"},
+		{field{typ: "unsigned", width: 8}, "(others => '0')"},
+		{field{typ: "t_state", width: 1, typGen: e}, "t_state'left"},
+		{field{typ: "t_inner", width: 1, typGen: inner}, "(s => t_state'left)"},
+		{field{typ: "t_foreign", width: 4}, "to_foreign((3 downto 0 => '0'))"},
+		{field{typ: "t_foreign", width: 4, toType: "conv"}, "conv((3 downto 0 => '0'))"},
+	}
+
+	for i, test := range tests {
+		got := fieldInitValue(test.f)
+		if got != test.want {
+			t.Errorf("[%d] got %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestRecordSerialize(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		width  int
+		length int
+		slice  string
+		err    string
+	}{
+		{[]string{"serialize=bytes"}, 17, 3, "slv(8 * (2 - i) + 7 downto 8 * (2 - i))", ""},
+		{[]string{"serialize=bytes", "endian=little"}, 16, 2, "slv(8 * i + 7 downto 8 * i)", ""},
+		{[]string{"serialize=words", "endian=big"}, 65, 3, "slv(32 * (2 - i) + 31 downto 32 * (2 - i))", ""},
+		{[]string{"serialize=nibbles"}, 8, 0, "", "invalid argument 'nibbles' for 'serialize' parameter, valid arguments are: 'bytes' and 'words'"},
+		{[]string{"endian=little"}, 8, 0, "", "'endian' parameter requires 'serialize' parameter"},
+	}
+
+	for i, test := range tests {
+		r := &record{name: "t_rec", fields: []field{{name: "a", typ: "std_logic_vector", width: test.width, offset: -1}}}
+		err := r.ParseArgs(test.args)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d] got error %v, want %q", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+		if r.serialLength() != test.length {
+			t.Errorf("[%d] got length %d, want %d", i, r.serialLength(), test.length)
+		}
+		if r.serialSlice() != test.slice {
+			t.Errorf("[%d] got slice %q, want %q", i, r.serialSlice(), test.slice)
+		}
+	}
+}
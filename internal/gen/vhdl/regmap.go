@@ -0,0 +1,562 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Register map is generated for records with the regmap flag. Each record field is
+// a single 32-bit register. The package gets address and reset constants, the entity
+// implementing the bus slave, C header and Markdown register table are generated
+// in the directory of the VHDL file.
+
+// validateRegmap checks register map fields and assigns addresses of fields without the addr argument.
+func (r *record) validateRegmap() error {
+	next := int64(0)
+	addrs := map[int64]string{}
+
+	for i := range r.fields {
+		f := &r.fields[i]
+
+		switch f.typ {
+		case "std_logic", "std_ulogic", "std_logic_vector", "unsigned", "signed":
+		default:
+			return fmt.Errorf("field '%s': type '%s' is not supported in register map", f.name, f.typ)
+		}
+		if f.width > 32 {
+			return fmt.Errorf("field '%s': width %d exceeds 32 bits register width", f.name, f.width)
+		}
+		if f.width < 64 && f.reset>>f.width != 0 {
+			return fmt.Errorf("field '%s': reset value 0x%x does not fit in %d bits", f.name, f.reset, f.width)
+		}
+
+		if f.access == "" {
+			f.access = "rw"
+		}
+		if f.addr < 0 {
+			f.addr = next
+		}
+		if f.addr%4 != 0 {
+			return fmt.Errorf("field '%s': address 0x%x is not aligned to 4 bytes", f.name, f.addr)
+		}
+		if r.base+uint64(f.addr) > 0xFFFFFFFF {
+			return fmt.Errorf("field '%s': address 0x%x exceeds 32-bit address space", f.name, r.base+uint64(f.addr))
+		}
+		if name, ok := addrs[f.addr]; ok {
+			return fmt.Errorf("field '%s': address 0x%x already used by field '%s'", f.name, f.addr, name)
+		}
+		addrs[f.addr] = f.name
+		next = f.addr + 4
+	}
+
+	return nil
+}
+
+// regmapName returns the name of the entity and files generated for the register map record.
+func regmapName(r *record) string { return cName(r.name) + "_regs" }
+
+// regResetValue returns VHDL literal of the register reset value.
+func regResetValue(f field) string {
+	if f.width == 1 && (f.typ == "std_logic" || f.typ == "std_ulogic") {
+		return fmt.Sprintf("'%d'", f.reset)
+	}
+	return fmt.Sprintf("\"%0*b\"", f.width, f.reset)
+}
+
+func (r *record) genRegmapConstantsDeclaration(b *strings.Builder) {
+	prefix := constNamePrefix(r.name)
+
+	b.WriteString(fmt.Sprintf("   constant %s_BASE : std_logic_vector(31 downto 0) := x\"%08x\";\n", prefix, r.base))
+	for _, f := range r.fields {
+		b.WriteString(
+			fmt.Sprintf(
+				"   constant %s_%s_ADDR : std_logic_vector(31 downto 0) := x\"%08x\";\n",
+				prefix, strings.ToUpper(f.name), r.base+uint64(f.addr),
+			),
+		)
+	}
+
+	resets := []string{}
+	for _, f := range r.fields {
+		resets = append(resets, fmt.Sprintf("%s => %s", f.name, regResetValue(f)))
+	}
+	b.WriteString(
+		fmt.Sprintf("   constant %s_RESET : %s := (%s);\n", prefix, r.name, strings.Join(resets, ", ")),
+	)
+}
+
+// genRegmaps generates register map files for records with the regmap flag.
+func genRegmaps(units []unit, vhdlFilepath string) error {
+	dir := filepath.Dir(vhdlFilepath)
+
+	for _, u := range units {
+		// Package body contains the same generables as the package.
+		if u.typ == "package body" {
+			continue
+		}
+		for _, g := range u.gens {
+			r, ok := g.(*record)
+			if !ok || !r.regmap {
+				continue
+			}
+			if u.typ != "package" {
+				return fmt.Errorf("%s %s: record '%s': register map record must be declared in package", u.typ, u.name, r.name)
+			}
+
+			name := regmapName(r)
+			files := []struct {
+				ext     string
+				content string
+			}{
+				{".vhd", genRegmapEntity(u.name, r)},
+				{".h", genRegmapCHeader(u.name, r)},
+				{".md", genRegmapMarkdown(u.name, r)},
+			}
+			for _, f := range files {
+				if err := emitFile(filepath.Join(dir, name+f.ext), []byte(f.content)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// regToData returns expression converting register value to std_logic_vector.
+func regToData(f field, value string) string {
+	switch f.typ {
+	case "unsigned", "signed":
+		return "std_logic_vector(" + value + ")"
+	}
+	return value
+}
+
+// regFromData returns expression converting std_logic_vector to register value.
+func regFromData(f field, value string) string {
+	switch f.typ {
+	case "unsigned", "signed":
+		return f.typ + "(" + value + ")"
+	}
+	return value
+}
+
+// regDataSlice returns the slice of the data bus holding the register.
+func regDataSlice(f field, data string) string {
+	if f.typ == "std_logic" || f.typ == "std_ulogic" {
+		return data + "(0)"
+	}
+	return fmt.Sprintf("%s(%d downto 0)", data, f.width-1)
+}
+
+// genRegmapWrite generates register writes decoding the addr bus.
+func genRegmapWrite(r *record, addr, wdata, strb, indent string, b *strings.Builder) {
+	prefix := constNamePrefix(r.name)
+
+	for _, f := range r.fields {
+		if f.access == "ro" {
+			continue
+		}
+
+		b.WriteString(
+			fmt.Sprintf(
+				"%[1]sif %[2]s(31 downto 2) = %[3]s_%[4]s_ADDR(31 downto 2) then\n"+
+					"%[1]s   data := (others => '0');\n",
+				indent, addr, prefix, strings.ToUpper(f.name),
+			),
+		)
+		slice := regDataSlice(f, "data")
+		if f.access == "w1c" {
+			b.WriteString(
+				fmt.Sprintf(
+					"%[1]s   data := strobe(data, %[2]s, %[3]s);\n"+
+						"%[1]s   v.%[4]s := v.%[4]s and not %[5]s;\n",
+					indent, wdata, strb, f.name, regFromData(f, slice),
+				),
+			)
+		} else {
+			b.WriteString(
+				fmt.Sprintf(
+					"%[1]s   %[2]s := %[3]s;\n"+
+						"%[1]s   data := strobe(data, %[4]s, %[5]s);\n"+
+						"%[1]s   v.%[6]s := %[7]s;\n",
+					indent, slice, regToData(f, "v."+f.name), wdata, strb, f.name, regFromData(f, slice),
+				),
+			)
+		}
+		b.WriteString(indent + "end if;\n")
+	}
+}
+
+// genRegmapRead generates register reads decoding the addr bus.
+func genRegmapRead(r *record, addr, indent string, b *strings.Builder) {
+	prefix := constNamePrefix(r.name)
+
+	for _, f := range r.fields {
+		src := "regs"
+		switch f.access {
+		case "wo":
+			// Write-only registers are read as zeros.
+			continue
+		case "ro":
+			src = "regs_i"
+		}
+
+		b.WriteString(
+			fmt.Sprintf(
+				"%[1]sif %[2]s(31 downto 2) = %[3]s_%[4]s_ADDR(31 downto 2) then\n"+
+					"%[1]s   %[5]s := %[6]s;\n"+
+					"%[1]send if;\n",
+				indent, addr, prefix, strings.ToUpper(f.name),
+				regDataSlice(f, "data"), regToData(f, src+"."+f.name),
+			),
+		)
+	}
+}
+
+// genRegmapW1CSet generates setting of write one to clear registers by the hardware.
+func genRegmapW1CSet(r *record, indent string, b *strings.Builder) {
+	for _, f := range r.fields {
+		if f.access == "w1c" {
+			b.WriteString(fmt.Sprintf("%[1]sv.%[2]s := v.%[2]s or regs_i.%[2]s;\n", indent, f.name))
+		}
+	}
+}
+
+type regmapPort struct {
+	name string
+	dir  string
+	typ  string
+}
+
+func genRegmapEntity(pkg string, r *record) string {
+	name := regmapName(r)
+	b := strings.Builder{}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"-- Below code was automatically generated with the thdl tool.\n"+
+				"-- Do not modify it by hand, unless you really know what you do.\n"+
+				"-- More info on https://github.com/m-kru/go-thdl.\n"+
+				"--\n"+
+				"-- Source: VHDL package '%s', record type '%s'.\n\n"+
+				"library ieee;\n"+
+				"   use ieee.std_logic_1164.all;\n"+
+				"   use ieee.numeric_std.all;\n\n"+
+				"library work;\n"+
+				"   use work.%s.all;\n\n",
+			pkg, r.name, pkg,
+		),
+	)
+
+	slv := func(width int) string { return fmt.Sprintf("std_logic_vector(%d downto 0)", width-1) }
+
+	ports := []regmapPort{{"clk_i", "in", "std_logic"}, {"rst_i", "in", "std_logic"}}
+	if r.bus == "wishbone" {
+		ports = append(ports,
+			regmapPort{"cyc_i", "in", "std_logic"},
+			regmapPort{"stb_i", "in", "std_logic"},
+			regmapPort{"we_i", "in", "std_logic"},
+			regmapPort{"adr_i", "in", slv(32)},
+			regmapPort{"sel_i", "in", slv(4)},
+			regmapPort{"dat_i", "in", slv(32)},
+			regmapPort{"dat_o", "out", slv(32)},
+			regmapPort{"ack_o", "out", "std_logic"},
+		)
+	} else {
+		ports = append(ports,
+			regmapPort{"awaddr_i", "in", slv(32)},
+			regmapPort{"awvalid_i", "in", "std_logic"},
+			regmapPort{"awready_o", "out", "std_logic"},
+			regmapPort{"wdata_i", "in", slv(32)},
+			regmapPort{"wstrb_i", "in", slv(4)},
+			regmapPort{"wvalid_i", "in", "std_logic"},
+			regmapPort{"wready_o", "out", "std_logic"},
+			regmapPort{"bresp_o", "out", slv(2)},
+			regmapPort{"bvalid_o", "out", "std_logic"},
+			regmapPort{"bready_i", "in", "std_logic"},
+			regmapPort{"araddr_i", "in", slv(32)},
+			regmapPort{"arvalid_i", "in", "std_logic"},
+			regmapPort{"arready_o", "out", "std_logic"},
+			regmapPort{"rdata_o", "out", slv(32)},
+			regmapPort{"rresp_o", "out", slv(2)},
+			regmapPort{"rvalid_o", "out", "std_logic"},
+			regmapPort{"rready_i", "in", "std_logic"},
+		)
+	}
+	ports = append(ports, regmapPort{"regs_i", "in", r.name}, regmapPort{"regs_o", "out", r.name})
+
+	nameLen := 0
+	for _, p := range ports {
+		if len(p.name) > nameLen {
+			nameLen = len(p.name)
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("entity %s is\n   port (\n", name))
+	for i, p := range ports {
+		sep := ";"
+		if i == len(ports)-1 {
+			sep = ""
+		}
+		b.WriteString(fmt.Sprintf("      %-*s : %-3s %s%s\n", nameLen, p.name, p.dir, p.typ, sep))
+	}
+	b.WriteString("   );\nend entity;\n\n")
+
+	b.WriteString(
+		fmt.Sprintf(
+			"architecture rtl of %s is\n\n"+
+				"   -- Returns data with bytes selected by the strobe replaced with bytes of the wdata.\n"+
+				"   function strobe(data, wdata : std_logic_vector(31 downto 0); strb : std_logic_vector(3 downto 0)) return std_logic_vector is\n"+
+				"      variable res : std_logic_vector(31 downto 0) := data;\n"+
+				"   begin\n"+
+				"      for i in 0 to 3 loop\n"+
+				"         if strb(i) = '1' then\n"+
+				"            res(8 * i + 7 downto 8 * i) := wdata(8 * i + 7 downto 8 * i);\n"+
+				"         end if;\n"+
+				"      end loop;\n"+
+				"      return res;\n"+
+				"   end function;\n\n"+
+				"   signal regs : %s := %s_RESET;\n\n",
+			name, r.name, constNamePrefix(r.name),
+		),
+	)
+
+	if r.bus == "wishbone" {
+		genRegmapWishbone(r, &b)
+	} else {
+		genRegmapAXI4Lite(r, &b)
+	}
+
+	b.WriteString("end architecture;\n")
+
+	return b.String()
+}
+
+func genRegmapAXI4Lite(r *record, b *strings.Builder) {
+	b.WriteString(
+		"   signal awready, wready, bvalid : std_logic := '0';\n" +
+			"   signal awaddr, wdata : std_logic_vector(31 downto 0) := (others => '0');\n" +
+			"   signal wstrb : std_logic_vector(3 downto 0) := (others => '0');\n" +
+			"   signal arready, rvalid : std_logic := '0';\n" +
+			"   signal araddr, rdata : std_logic_vector(31 downto 0) := (others => '0');\n\n" +
+			"begin\n\n" +
+			"   regs_o <= regs;\n\n" +
+			"   awready_o <= awready;\n" +
+			"   wready_o  <= wready;\n" +
+			"   bresp_o   <= \"00\";\n" +
+			"   bvalid_o  <= bvalid;\n" +
+			"   arready_o <= arready;\n" +
+			"   rdata_o   <= rdata;\n" +
+			"   rresp_o   <= \"00\";\n" +
+			"   rvalid_o  <= rvalid;\n\n",
+	)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   bus_write : process (clk_i) is\n"+
+				"      variable v    : %s;\n"+
+				"      variable data : std_logic_vector(31 downto 0);\n"+
+				"   begin\n"+
+				"      if rising_edge(clk_i) then\n"+
+				"         v := regs;\n"+
+				"         awready <= '0';\n"+
+				"         wready  <= '0';\n"+
+				"         if bvalid = '1' and bready_i = '1' then\n"+
+				"            bvalid <= '0';\n"+
+				"         end if;\n\n"+
+				"         if awvalid_i = '1' and wvalid_i = '1' and awready = '0' and bvalid = '0' then\n"+
+				"            awready <= '1';\n"+
+				"            wready  <= '1';\n"+
+				"            awaddr  <= awaddr_i;\n"+
+				"            wdata   <= wdata_i;\n"+
+				"            wstrb   <= wstrb_i;\n"+
+				"         end if;\n\n"+
+				"         -- Write response follows the cycle of the address and data handshake.\n"+
+				"         if awready = '1' and wready = '1' then\n"+
+				"            bvalid <= '1';\n",
+			r.name,
+		),
+	)
+	genRegmapWrite(r, "awaddr", "wdata", "wstrb", "            ", b)
+	b.WriteString("         end if;\n\n")
+	genRegmapW1CSet(r, "         ", b)
+	b.WriteString(
+		fmt.Sprintf(
+			"         regs <= v;\n\n"+
+				"         if rst_i = '1' then\n"+
+				"            regs    <= %s_RESET;\n"+
+				"            awready <= '0';\n"+
+				"            wready  <= '0';\n"+
+				"            bvalid  <= '0';\n"+
+				"         end if;\n"+
+				"      end if;\n"+
+				"   end process;\n\n",
+			constNamePrefix(r.name),
+		),
+	)
+
+	b.WriteString(
+		"   bus_read : process (clk_i) is\n" +
+			"      variable data : std_logic_vector(31 downto 0);\n" +
+			"   begin\n" +
+			"      if rising_edge(clk_i) then\n" +
+			"         arready <= '0';\n" +
+			"         if rvalid = '1' and rready_i = '1' then\n" +
+			"            rvalid <= '0';\n" +
+			"         end if;\n\n" +
+			"         if arvalid_i = '1' and arready = '0' and rvalid = '0' then\n" +
+			"            arready <= '1';\n" +
+			"            araddr  <= araddr_i;\n" +
+			"         end if;\n\n" +
+			"         -- Read data follows the cycle of the address handshake.\n" +
+			"         if arready = '1' then\n" +
+			"            rvalid <= '1';\n" +
+			"            data := (others => '0');\n",
+	)
+	genRegmapRead(r, "araddr", "            ", b)
+	b.WriteString(
+		"            rdata <= data;\n" +
+			"         end if;\n\n" +
+			"         if rst_i = '1' then\n" +
+			"            arready <= '0';\n" +
+			"            rvalid  <= '0';\n" +
+			"         end if;\n" +
+			"      end if;\n" +
+			"   end process;\n\n",
+	)
+}
+
+func genRegmapWishbone(r *record, b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"   signal ack : std_logic := '0';\n"+
+				"   signal dat : std_logic_vector(31 downto 0) := (others => '0');\n\n"+
+				"begin\n\n"+
+				"   regs_o <= regs;\n\n"+
+				"   ack_o <= ack;\n"+
+				"   dat_o <= dat;\n\n"+
+				"   bus_access : process (clk_i) is\n"+
+				"      variable v    : %s;\n"+
+				"      variable data : std_logic_vector(31 downto 0);\n"+
+				"   begin\n"+
+				"      if rising_edge(clk_i) then\n"+
+				"         v := regs;\n"+
+				"         ack <= '0';\n\n"+
+				"         if cyc_i = '1' and stb_i = '1' and ack = '0' then\n"+
+				"            ack <= '1';\n"+
+				"            if we_i = '1' then\n",
+			r.name,
+		),
+	)
+	genRegmapWrite(r, "adr_i", "dat_i", "sel_i", "               ", b)
+	b.WriteString(
+		"            else\n" +
+			"               data := (others => '0');\n",
+	)
+	genRegmapRead(r, "adr_i", "               ", b)
+	b.WriteString(
+		"               dat <= data;\n" +
+			"            end if;\n" +
+			"         end if;\n\n",
+	)
+	genRegmapW1CSet(r, "         ", b)
+	b.WriteString(
+		fmt.Sprintf(
+			"         regs <= v;\n\n"+
+				"         if rst_i = '1' then\n"+
+				"            regs <= %s_RESET;\n"+
+				"            ack  <= '0';\n"+
+				"         end if;\n"+
+				"      end if;\n"+
+				"   end process;\n\n",
+			constNamePrefix(r.name),
+		),
+	)
+}
+
+func genRegmapCHeader(pkg string, r *record) string {
+	b := strings.Builder{}
+	prefix := strings.ToUpper(regmapName(r))
+
+	b.WriteString(
+		fmt.Sprintf(
+			"/*\n"+
+				" * Below code was automatically generated with the thdl tool.\n"+
+				" * Do not modify it by hand, unless you really know what you do.\n"+
+				" * More info on https://github.com/m-kru/go-thdl.\n"+
+				" *\n"+
+				" * Source: VHDL package '%[1]s', record type '%[2]s'.\n"+
+				" */\n\n"+
+				"#ifndef %[3]s_H\n"+
+				"#define %[3]s_H\n\n"+
+				"#include <stdint.h>\n\n"+
+				"#define %[3]s_BASE UINT32_C(0x%08[4]x)\n",
+			pkg, r.name, prefix, r.base,
+		),
+	)
+
+	for _, f := range r.fields {
+		fPrefix := prefix + "_" + strings.ToUpper(f.name)
+		b.WriteString(
+			fmt.Sprintf(
+				"\n/* Register %[2]s, access %[3]s. */\n"+
+					"#define %[1]s_OFFSET 0x%02[4]x\n"+
+					"#define %[1]s_ADDR UINT32_C(0x%08[5]x)\n"+
+					"#define %[1]s_WIDTH %[6]d\n"+
+					"#define %[1]s_MASK %[7]s\n"+
+					"#define %[1]s_RESET UINT32_C(0x%08[8]x)\n",
+				fPrefix, f.name, f.access, f.addr, r.base+uint64(f.addr), f.width, cMask(f.width, 0, 32), f.reset,
+			),
+		)
+	}
+
+	b.WriteString(fmt.Sprintf("\n#endif /* %s_H */\n", prefix))
+
+	return b.String()
+}
+
+func genRegmapMarkdown(pkg string, r *record) string {
+	b := strings.Builder{}
+
+	bus := "AXI4-Lite"
+	if r.bus == "wishbone" {
+		bus = "Wishbone"
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"<!--\n"+
+				"Below code was automatically generated with the thdl tool.\n"+
+				"Do not modify it by hand, unless you really know what you do.\n"+
+				"More info on https://github.com/m-kru/go-thdl.\n"+
+				"-->\n\n"+
+				"# %s\n\n"+
+				"Register map of the `%s` record type declared in the `%s` package.\n\n"+
+				"Bus: %s, base address: 0x%08x.\n\n"+
+				"| Register | Address | Offset | Access | Width | Reset |\n"+
+				"| --- | --- | --- | --- | --- | --- |\n",
+			regmapName(r), r.name, pkg, bus, r.base,
+		),
+	)
+
+	for _, f := range r.fields {
+		b.WriteString(
+			fmt.Sprintf(
+				"| %s | 0x%08x | 0x%02x | %s | %d | 0x%0*x |\n",
+				f.name, r.base+uint64(f.addr), f.addr, f.access, f.width, (f.width+3)/4, f.reset,
+			),
+		)
+	}
+
+	b.WriteString(
+		"\nAccess types:\n" +
+			"- rw - read-write,\n" +
+			"- ro - read-only, value is driven by the hardware with the regs_i port,\n" +
+			"- wo - write-only, register is read as zero,\n" +
+			"- w1c - write one to clear, bits are set by the hardware with the regs_i port.\n",
+	)
+
+	return b.String()
+}
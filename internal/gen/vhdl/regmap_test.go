@@ -0,0 +1,67 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestRegmapValidate(t *testing.T) {
+	var tests = []struct {
+		fields []field
+		addrs  []int64
+		err    string
+	}{
+		{
+			fields: []field{
+				{name: "a", typ: "std_logic", width: 1, addr: -1},
+				{name: "b", typ: "unsigned", width: 16, addr: 0x10},
+				{name: "c", typ: "std_logic_vector", width: 8, addr: -1},
+			},
+			addrs: []int64{0x0, 0x10, 0x14},
+		},
+		{
+			fields: []field{
+				{name: "a", typ: "std_logic", width: 1, addr: 0x4},
+				{name: "b", typ: "std_logic", width: 1, addr: 0x4},
+			},
+			err: "field 'b': address 0x4 already used by field 'a'",
+		},
+		{
+			fields: []field{{name: "a", typ: "std_logic_vector", width: 8, addr: 0x2}},
+			err:    "field 'a': address 0x2 is not aligned to 4 bytes",
+		},
+		{
+			fields: []field{{name: "a", typ: "std_logic_vector", width: 33, addr: -1}},
+			err:    "field 'a': width 33 exceeds 32 bits register width",
+		},
+		{
+			fields: []field{{name: "a", typ: "std_logic_vector", width: 4, addr: -1, reset: 0x10}},
+			err:    "field 'a': reset value 0x10 does not fit in 4 bits",
+		},
+		{
+			fields: []field{{name: "a", typ: "boolean", width: 1, addr: -1}},
+			err:    "field 'a': type 'boolean' is not supported in register map",
+		},
+	}
+
+	for i, test := range tests {
+		r := &record{name: "t_regs", fields: test.fields, regmap: true}
+		err := r.validateRegmap()
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d] got error %v, want %q", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+		for j, f := range r.fields {
+			if f.addr != test.addrs[j] {
+				t.Errorf("[%d] field '%s': got address 0x%x, want 0x%x", i, f.name, f.addr, test.addrs[j])
+			}
+			if f.access != "rw" {
+				t.Errorf("[%d] field '%s': got access '%s', want 'rw'", i, f.name, f.access)
+			}
+		}
+	}
+}
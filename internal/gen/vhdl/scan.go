@@ -8,6 +8,7 @@ import (
 	"github.com/m-kru/go-thdl/internal/utils"
 	"github.com/m-kru/go-thdl/internal/vhdl"
 	"github.com/m-kru/go-thdl/internal/vhdl/re"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -20,7 +21,8 @@ func scanFile(fileContent []byte) ([]unit, error) {
 	unit := unit{}
 
 	scanner := bufio.NewScanner(bytes.NewReader(fileContent))
-	sCtx := scanContext{scanner: scanner}
+	sCtx := scanContext{scanner: scanner, lookup: fileLookup(fileContent), uses: scanUseClauses(fileContent)}
+	libUses := scanLibraryUseClauses(fileContent)
 
 	appendUnit := func() {
 		if unit.name != "" && len(unit.gens) > 0 {
@@ -35,12 +37,14 @@ func scanFile(fileContent []byte) ([]unit, error) {
 			unit.lineNum = sCtx.lineNum
 			unit.typ = "architecture"
 			unit.gens = gen.Container{}
+			unit.uses = libUses
 		} else if sm := re.PackageDeclaration.FindSubmatchIndex(sCtx.line); len(sm) > 0 {
 			appendUnit()
 			unit.name = string(sCtx.line[sm[2]:sm[3]])
 			unit.lineNum = sCtx.lineNum
 			unit.typ = "package"
 			unit.gens = gen.Container{}
+			unit.uses = libUses
 		} else if sm := re.PackageBodyDeclaration.FindSubmatchIndex(sCtx.line); len(sm) > 0 {
 			name := string(sCtx.line[sm[2]:sm[3]])
 			if strings.ToLower(name) == strings.ToLower(unit.name) {
@@ -82,6 +86,9 @@ func scanGenerable(sCtx *scanContext, gens gen.Container) (gen.Generable, error)
 	} else if sm := re.RecordTypeDeclaration.FindSubmatchIndex(sCtx.line); len(sm) > 0 {
 		name := string(sCtx.line[sm[2]:sm[3]])
 		return scanRecordTypeDeclaration(sCtx, gens, name, args)
+	} else if sm := re.ArrayTypeDeclaration.FindSubmatchIndex(sCtx.line); len(sm) > 0 {
+		name := string(sCtx.line[sm[2]:sm[3]])
+		return scanArrayTypeDeclaration(sCtx, gens, name, args)
 	}
 
 	return nil, fmt.Errorf("line %d: cannot process line\n%s", sCtx.lineNum, sCtx.line)
@@ -90,6 +97,7 @@ func scanGenerable(sCtx *scanContext, gens gen.Container) (gen.Generable, error)
 // scanEnumTypeDeclaration assumes that current line in the scanContext contains the '(' character.
 func scanEnumTypeDeclaration(sCtx *scanContext, name string, args []string) (*enum, error) {
 	enum := enum{name: name, values: []string{}}
+	lineNum := sCtx.lineNum
 
 	err := enum.ParseArgs(args)
 	if err != nil {
@@ -98,13 +106,19 @@ func scanEnumTypeDeclaration(sCtx *scanContext, name string, args []string) (*en
 
 	sCtx.line = bytes.Split((sCtx.line), []byte("("))[1]
 	for {
+		literalArgs := ""
+		if len(thdlFieldArgs.FindIndex(sCtx.line)) > 0 {
+			literalArgs = string(bytes.Split(sCtx.line, []byte("--thdl:"))[1])
+		}
+		valuesCount := len(enum.values)
+
 		sCtx.decomment()
 		vals := bytes.Split(sCtx.line, []byte(","))
 		for _, v := range vals {
+			v = bytes.Trim(v, " \t")
 			if len(v) == 0 {
 				continue
 			}
-			v = bytes.Trim(v, " \t")
 			if v[len(v)-1] == ')' || v[len(v)-1] == ';' {
 				v = v[:len(v)-1]
 				v = bytes.Trim(v, " \t")
@@ -117,6 +131,19 @@ func scanEnumTypeDeclaration(sCtx *scanContext, name string, args []string) (*en
 				continue
 			}
 			enum.values = append(enum.values, string(bytes.Trim(v, " \t")))
+			enum.explicitValues = append(enum.explicitValues, -1)
+		}
+
+		if literalArgs != "" {
+			if len(enum.values)-valuesCount != 1 {
+				return nil, fmt.Errorf(
+					"line %d: enum '%s': literal arguments require single literal in line", sCtx.lineNum, name,
+				)
+			}
+			err := enum.parseLiteralArgs(enum.values[len(enum.values)-1], literalArgs)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: enum '%s': %v", sCtx.lineNum, name, err)
+			}
 		}
 
 		if bytes.Contains(sCtx.line, []byte(")")) {
@@ -126,11 +153,60 @@ func scanEnumTypeDeclaration(sCtx *scanContext, name string, args []string) (*en
 		sCtx.scan()
 	}
 
+	err = enum.validate()
+	if err != nil {
+		return nil, fmt.Errorf("line %d: enum '%s': %v", lineNum, name, err)
+	}
+
 	return &enum, nil
 }
 
+var arrayDefinition *regexp.Regexp = regexp.MustCompile(`(?i)\barray\s*\((.+)\)\s*of\s+(.+?)\s*;`)
+
+// scanArrayTypeDeclaration scans array type declaration. The declaration may span multiple lines.
+func scanArrayTypeDeclaration(sCtx *scanContext, gens gen.Container, name string, args []string) (*array, error) {
+	array := array{name: name}
+	lineNum := sCtx.lineNum
+
+	err := array.ParseArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: array '%s': %v", lineNum, name, err)
+	}
+
+	sCtx.decomment()
+	decl := string(sCtx.line)
+	for !strings.Contains(decl, ";") {
+		if !sCtx.scan() {
+			return nil, fmt.Errorf("line %d: array '%s': cannot scan declaration, EOF", lineNum, name)
+		}
+		sCtx.decomment()
+		decl += " " + string(sCtx.line)
+	}
+
+	sm := arrayDefinition.FindStringSubmatch(decl)
+	if len(sm) == 0 {
+		return nil, fmt.Errorf("line %d: array '%s': cannot parse declaration", lineNum, name)
+	}
+
+	if strings.Contains(sm[1], "<>") {
+		return nil, fmt.Errorf("line %d: array '%s': unconstrained arrays are not supported", lineNum, name)
+	}
+	array.left, array.right, err = parseRange(sCtx, sm[1])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: array '%s': %v", lineNum, name, err)
+	}
+
+	err = parseFieldType(sCtx, strings.ToLower(sm[2]), gens, &array.elem)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: array '%s': element: %v", lineNum, name, err)
+	}
+
+	return &array, nil
+}
+
 func scanRecordTypeDeclaration(sCtx *scanContext, gens gen.Container, name string, args []string) (*record, error) {
 	record := record{name: name}
+	lineNum := sCtx.lineNum
 
 	err := record.ParseArgs(args)
 	if err != nil {
@@ -145,17 +221,22 @@ func scanRecordTypeDeclaration(sCtx *scanContext, gens gen.Container, name strin
 		} else if len(re.EndRecord.FindIndex(sCtx.line)) > 0 {
 			break
 		} else {
-			err := parseRecordFieldLine(sCtx.line, gens, &record)
+			err := parseRecordFieldLine(sCtx, gens, &record)
 			if err != nil {
 				return nil, fmt.Errorf("line %d: record '%s': %v", sCtx.lineNum, name, err)
 			}
 		}
 	}
 
+	if err := record.validate(); err != nil {
+		return nil, fmt.Errorf("line %d: record '%s': %v", lineNum, name, err)
+	}
+
 	return &record, nil
 }
 
-func parseRecordFieldLine(line []byte, gens gen.Container, r *record) error {
+func parseRecordFieldLine(sCtx *scanContext, gens gen.Container, r *record) error {
+	line := sCtx.line
 	args := ""
 	if len(thdlFieldArgs.FindIndex(line)) > 0 {
 		splits := bytes.Split(line, []byte("--thdl:"))
@@ -166,43 +247,61 @@ func parseRecordFieldLine(line []byte, gens gen.Container, r *record) error {
 	line = bytes.Trim(line, " \t")
 	splits := bytes.Split(line, []byte(":"))
 
-	f := field{name: string(bytes.Trim(splits[0], " \t"))}
+	f := field{name: string(bytes.Trim(splits[0], " \t")), offset: -1, addr: -1}
 
 	splits = bytes.Split(splits[1], []byte(";"))
 	typ := string(bytes.ToLower(bytes.Trim(splits[0], " \t")))
 
 	var err error
 	if args != "" {
-		err = parseRecordFieldWithArgs(typ, &f, args, r)
-	} else if vhdl.IsSingleBitStdType(typ) {
+		err = parseRecordFieldWithArgs(sCtx, typ, gens, &f, args)
+	} else {
+		err = parseFieldType(sCtx, typ, gens, &f)
+	}
+	if err != nil {
+		return fmt.Errorf("field '%s': %v", f.name, err)
+	}
+
+	r.fields = append(r.fields, f)
+
+	return nil
+}
+
+// parseFieldType sets type and width of the record field or array element.
+func parseFieldType(sCtx *scanContext, typ string, gens gen.Container, f *field) error {
+	if vhdl.IsSingleBitStdType(typ) {
 		f.typ = typ
 		f.width = 1
 	} else if strings.Contains(typ, "(") {
-		err = parseRecordVectorField(typ, &f, r)
+		return parseVectorField(sCtx, typ, f)
 	} else if strings.HasPrefix(typ, "integer") ||
 		strings.HasPrefix(typ, "natural") ||
 		strings.HasPrefix(typ, "positive") {
-		err = parseRecordIntegerField(typ, &f, r)
+		return parseIntegerField(typ, f)
 	} else {
 		if g, ok := gens.Get(typ); ok {
 			f.typ = typ
 			f.width = g.Width()
+			f.typGen = g
+		} else if g, err := sCtx.lookupTreeGenerable(typ); err != nil {
+			return err
+		} else if g != nil {
+			// Conversion functions are generated in other package, so the type is handled as a foreign type.
+			f.typ = typ
+			f.width = g.Width()
+			f.typGen = g
 		} else {
-			return fmt.Errorf("field '%s' has unknown type '%s'", f.name, typ)
+			return fmt.Errorf("unknown type '%s'", typ)
 		}
 	}
-	if err != nil {
-		return fmt.Errorf("field '%s': %v", f.name, err)
-	}
-
-	r.fields = append(r.fields, f)
 
 	return nil
 }
 
-func parseRecordFieldWithArgs(typ string, f *field, args string, r *record) error {
+func parseRecordFieldWithArgs(sCtx *scanContext, typ string, gens gen.Container, f *field, args string) error {
 	validParams := map[string]bool{
-		"width": true, "to-type": true, "to-slv": true, "to-str": true,
+		"width": true, "to-type": true, "to-slv": true, "to-str": true, "pad": true, "offset": true,
+		"access": true, "addr": true, "reset": true,
 	}
 
 	f.typ = typ
@@ -234,9 +333,12 @@ func parseRecordFieldWithArgs(typ string, f *field, args string, r *record) erro
 		}
 		switch param {
 		case "width":
-			w, err := strconv.Atoi(value)
+			w, err := sCtx.evalInt(value)
 			if err != nil {
-				return fmt.Errorf("cannot parse value for 'width' parameter: %v", err)
+				return fmt.Errorf("cannot evaluate value for 'width' parameter: %v", err)
+			}
+			if w <= 0 {
+				return fmt.Errorf("value for 'width' parameter must be positive, current value %d", w)
 			}
 			f.width = w
 			widthPresent = true
@@ -246,59 +348,111 @@ func parseRecordFieldWithArgs(typ string, f *field, args string, r *record) erro
 			f.toSlv = value
 		case "to-str":
 			f.toStr = value
+		case "pad", "offset":
+			v, err := sCtx.evalInt(value)
+			if err != nil {
+				return fmt.Errorf("cannot evaluate value for '%s' parameter: %v", param, err)
+			}
+			if v < 0 {
+				return fmt.Errorf("value for '%s' parameter must not be negative, current value %d", param, v)
+			}
+			if param == "pad" {
+				f.pad = v
+			} else {
+				f.offset = v
+			}
+		case "access":
+			switch value {
+			case "rw", "ro", "wo", "w1c":
+				f.access = value
+			default:
+				return fmt.Errorf(
+					"invalid value '%s' for 'access' parameter, valid values are: 'rw', 'ro', 'wo' and 'w1c'", value,
+				)
+			}
+		case "addr":
+			v, err := strconv.ParseUint(value, 0, 32)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s' for 'addr' parameter, must be 32-bit unsigned integer", value)
+			}
+			f.addr = int64(v)
+		case "reset":
+			v, err := strconv.ParseUint(value, 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s' for 'reset' parameter, must be unsigned integer", value)
+			}
+			f.reset = v
 		default:
-			panic(fmt.Sprintf("missing vlaue handling for parameter '%s'", param))
+			return fmt.Errorf("missing value handling for parameter '%s'", param)
 		}
 	}
 
 	if !widthPresent {
-		return fmt.Errorf("'width' parameter must be set as type '%s' is unknown", typ)
+		// Width is required only for foreign types, padding and offset might be set for any type.
+		if f.toType != "" || f.toSlv != "" || f.toStr != "" {
+			return fmt.Errorf("'width' parameter must be set as type '%s' is unknown", typ)
+		}
+		return parseFieldType(sCtx, typ, gens, f)
 	}
 
 	return nil
 }
 
-func parseRecordVectorField(typ string, f *field, r *record) error {
-	splits := strings.Split(typ, "(")
-	f.typ = strings.Trim(splits[0], " \t")
-	range_ := strings.Trim(splits[1], " \t")
-	if range_[len(range_)-1] == ')' {
-		range_ = range_[:len(range_)-1]
+func parseVectorField(sCtx *scanContext, typ string, f *field) error {
+	f.typ = strings.Trim(typ[:strings.Index(typ, "(")], " \t")
+	range_ := typ[strings.Index(typ, "(")+1:]
+	if i := strings.LastIndex(range_, ")"); i >= 0 {
+		range_ = range_[:i]
 	}
 
-	if sm := re.SimpleRange.FindStringSubmatchIndex(range_); len(sm) > 0 {
-		expr1 := string(range_[sm[2]:sm[3]])
-		dir := strings.ToLower(string(range_[sm[4]:sm[5]]))
-		expr2 := string(range_[sm[6]:sm[7]])
+	left, right, err := parseRange(sCtx, range_)
+	if err != nil {
+		return err
+	}
+	f.width = rangeLength(left, right)
 
-		val1, err := strconv.ParseInt(expr1, 0, 32)
-		if err != nil {
-			return fmt.Errorf("cannot parse '%s' expression to int", expr1)
-		}
-		val2, err := strconv.ParseInt(expr2, 0, 32)
-		if err != nil {
-			return fmt.Errorf("cannot parse '%s' expression to int", expr2)
-		}
+	return nil
+}
 
-		if dir == "downto" {
-			f.width = int(val1 - val2 + 1)
-		} else if dir == "to" {
-			f.width = int(val2 - val1 + 1)
-		} else {
-			panic("should never happen")
-		}
-	} else {
-		panic("not yet implemented")
+// parseRange returns left and right bound of the range.
+func parseRange(sCtx *scanContext, range_ string) (int, int, error) {
+	sm := re.SimpleRange.FindStringSubmatchIndex(range_)
+	if len(sm) == 0 {
+		return 0, 0, fmt.Errorf("unsupported range '%s'", range_)
 	}
 
-	return nil
+	expr1 := string(range_[sm[2]:sm[3]])
+	dir := strings.ToLower(string(range_[sm[4]:sm[5]]))
+	expr2 := string(range_[sm[6]:sm[7]])
+
+	left, err := sCtx.evalInt(expr1)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot evaluate '%s' expression: %v", strings.TrimSpace(expr1), err)
+	}
+	right, err := sCtx.evalInt(expr2)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot evaluate '%s' expression: %v", strings.TrimSpace(expr2), err)
+	}
+
+	if (dir == "downto" && left < right) || (dir == "to" && left > right) {
+		return 0, 0, fmt.Errorf("null range '%s'", range_)
+	}
+
+	return left, right, nil
+}
+
+func rangeLength(left, right int) int {
+	if left > right {
+		return left - right + 1
+	}
+	return right - left + 1
 }
 
-func parseRecordIntegerField(typ string, f *field, r *record) error {
+func parseIntegerField(typ string, f *field) error {
 	ranged := strings.Contains(typ, "range")
 
 	if ranged {
-		panic("not yet supported")
+		return fmt.Errorf("integer types with range constraint are not yet supported")
 	} else {
 		if typ[0:3] == "int" {
 			f.typ = "integer"
@@ -3,12 +3,26 @@ package vhdl
 import (
 	"bufio"
 	"bytes"
+	"fmt"
+	"github.com/m-kru/go-thdl/internal/vhdl"
 )
 
 type scanContext struct {
 	scanner *bufio.Scanner
 	lineNum uint
 	line    []byte
+	// lookup returns value of the integer constant, it is used for evaluating expressions.
+	lookup func(name string) (int, error)
+	// uses are lowercase suffixes of selected names from use clauses, for example 'pkg.all'.
+	uses []string
+}
+
+func (sc *scanContext) evalInt(expr string) (int, error) {
+	lookup := sc.lookup
+	if lookup == nil {
+		lookup = func(name string) (int, error) { return 0, fmt.Errorf("unknown identifier '%s'", name) }
+	}
+	return vhdl.EvalInt(expr, lookup)
 }
 
 // scan returns false on EOF.
@@ -0,0 +1,174 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+// genSVPackages generates SystemVerilog package for each package with generables.
+// The package name is the VHDL package name with the '_sv' suffix, so that both
+// packages can be compiled into the same library.
+func genSVPackages(units []unit) error {
+	for _, u := range units {
+		if u.typ != "package" {
+			continue
+		}
+
+		name := strings.ToLower(u.name) + "_sv"
+		content := genSVPackage(u.name, name, u.gens)
+
+		path := filepath.Join(genArgs.SVDir, name+".sv")
+		if err := emitFile(path, []byte(content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genSVPackage(vhdlPkg string, pkg string, gens gen.Container) string {
+	b := strings.Builder{}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"// Below code was automatically generated with the thdl tool.\n"+
+				"// Do not modify it by hand, unless you really know what you do.\n"+
+				"// More info on https://github.com/m-kru/go-thdl.\n"+
+				"//\n"+
+				"// Source: VHDL package '%s'.\n\n"+
+				"package %s;\n",
+			vhdlPkg, pkg,
+		),
+	)
+
+	for _, g := range gens {
+		b.WriteRune('\n')
+
+		switch g := g.(type) {
+		case *enum:
+			genSVEnum(g, &b)
+		case *record:
+			genSVRecord(g, gens, &b)
+		case *array:
+			genSVArray(g, gens, &b)
+		default:
+			panic("should never happen")
+		}
+	}
+
+	b.WriteString("\nendpackage\n")
+
+	return b.String()
+}
+
+// svTypeName returns the SystemVerilog name of the type, for example 'status_t' for 't_status'.
+func svTypeName(typeName string) string {
+	return cName(typeName) + "_t"
+}
+
+// svType returns the SystemVerilog base type and packed dimensions of the field.
+func svType(f field, gens gen.Container) (string, string) {
+	switch f.typ {
+	case "std_logic", "std_ulogic", "bit", "boolean":
+		return "logic", ""
+	case "integer", "signed":
+		return "logic signed", fmt.Sprintf("[%d:0]", f.width-1)
+	}
+
+	if g, ok := gens.Get(f.typ); ok {
+		return svTypeName(g.Name()), ""
+	}
+
+	return "logic", fmt.Sprintf("[%d:0]", f.width-1)
+}
+
+func genSVEnum(e *enum, b *strings.Builder) {
+	prefix := strings.ToUpper(cName(e.name))
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   // Enumeration type %s, %s encoding.\n"+
+				"   typedef enum logic [%d:0] {\n",
+			e.name, e.encoding, e.Width()-1,
+		),
+	)
+
+	for i, v := range e.values {
+		sep := ","
+		if i == len(e.values)-1 {
+			sep = ""
+		}
+		b.WriteString(fmt.Sprintf("      %s_%s = %d'b%s%s\n", prefix, strings.ToUpper(v), e.Width(), e.bits(i), sep))
+	}
+
+	b.WriteString(fmt.Sprintf("   } %s;\n", svTypeName(e.name)))
+}
+
+func genSVRecord(r *record, gens gen.Container, b *strings.Builder) {
+	ranges, width, _ := r.layout()
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   // Record type %s.\n"+
+				"   localparam int %s_WIDTH = %d;\n\n"+
+				"   typedef struct packed {\n",
+			r.name, strings.ToUpper(cName(r.name)), width,
+		),
+	)
+
+	// Members of packed structures are placed starting from the most significant bits.
+	idxs := make([]int, len(r.fields))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.Slice(idxs, func(i, j int) bool { return ranges[idxs[i]].lo > ranges[idxs[j]].lo })
+
+	pads := 0
+	pad := func(hi int, lo int) {
+		if hi < lo {
+			return
+		}
+		b.WriteString(fmt.Sprintf("      logic [%d:0] _pad%d;\n", hi-lo, pads))
+		pads += 1
+	}
+
+	msb := width - 1
+	for _, i := range idxs {
+		f := r.fields[i]
+		pad(msb, ranges[i].hi+1)
+
+		typ, dims := svType(f, gens)
+		if dims != "" {
+			typ += " " + dims
+		}
+		b.WriteString(fmt.Sprintf("      %s %s;\n", typ, strings.ToLower(f.name)))
+
+		msb = ranges[i].lo - 1
+	}
+	pad(msb, 0)
+
+	b.WriteString(fmt.Sprintf("   } %s;\n", svTypeName(r.name)))
+}
+
+func genSVArray(a *array, gens gen.Container, b *strings.Builder) {
+	// The element at the left index of the packed dimension is placed at the most significant bits.
+	left, right := a.left, a.right
+	if a.lsbFirst {
+		left, right = right, left
+	}
+
+	typ, dims := svType(a.elem, gens)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"   // Array type %s.\n"+
+				"   localparam int %s_WIDTH = %d;\n\n"+
+				"   typedef %s [%d:%d]%s %s;\n",
+			a.name, strings.ToUpper(cName(a.name)), a.Width(), typ, left, right, dims, svTypeName(a.name),
+		),
+	)
+}
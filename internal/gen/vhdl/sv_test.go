@@ -0,0 +1,33 @@
+package vhdl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+func TestSVRecordPadding(t *testing.T) {
+	r := record{
+		name: "t_rec",
+		fields: []field{
+			{name: "a", typ: "std_logic", width: 1, offset: -1, pad: 2},
+			{name: "b", typ: "std_logic_vector", width: 4, offset: 8},
+		},
+		lsbFirst: true,
+		align:    16,
+	}
+
+	b := strings.Builder{}
+	genSVRecord(&r, gen.Container{}, &b)
+
+	want := "   typedef struct packed {\n" +
+		"      logic [3:0] _pad0;\n" +
+		"      logic [3:0] b;\n" +
+		"      logic [6:0] _pad1;\n" +
+		"      logic a;\n" +
+		"   } rec_t;\n"
+	if !strings.HasSuffix(b.String(), want) {
+		t.Errorf("got\n%s\nwant suffix\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,387 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+// Number of random values checked by record testbenches.
+const testbenchIterations = 1000
+
+// genTestbenches generates self-checking round-trip testbench for each enumeration
+// and record declared in packages. Testbenches are named after types with the 'tb_' prefix.
+func genTestbenches(units []unit) error {
+	for _, u := range units {
+		if u.typ != "package" {
+			continue
+		}
+
+		for _, g := range u.gens {
+			var content string
+			switch g := g.(type) {
+			case *enum:
+				content = genEnumTestbench(u.name, g)
+			case *record:
+				content = genRecordTestbench(u.name, u.gens, u.uses, g)
+			default:
+				continue
+			}
+
+			path := filepath.Join(genArgs.TestbenchDir, testbenchName(g)+".vhd")
+			if err := emitFile(path, []byte(content)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func testbenchName(g gen.Generable) string { return "tb_" + cName(g.Name()) }
+
+// genTestbenchHeader generates the context clause and the entity. Use clauses of the
+// source file are also included, as they might be required for foreign types.
+func genTestbenchHeader(pkgs []string, uses []string, typ string, g gen.Generable, b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"-- Below code was automatically generated with the thdl tool.\n"+
+				"-- Do not modify it by hand, unless you really know what you do.\n"+
+				"-- More info on https://github.com/m-kru/go-thdl.\n"+
+				"--\n"+
+				"-- Source: VHDL package '%s', %s type '%s'.\n\n"+
+				"library ieee;\n"+
+				"   use ieee.std_logic_1164.all;\n"+
+				"   use ieee.numeric_std.all;\n"+
+				"   use ieee.math_real.all;\n\n",
+			pkgs[0], typ, g.Name(),
+		),
+	)
+	genUseClauses(pkgs, uses, b)
+
+	name := testbenchName(g)
+	b.WriteString(fmt.Sprintf("\nentity %[1]s is\nend entity;\n\narchitecture test of %[1]s is\n", name))
+}
+
+// genUseClauses generates use clauses for the work packages, followed by use clauses
+// of the source file grouped by libraries.
+func genUseClauses(pkgs []string, uses []string, b *strings.Builder) {
+	b.WriteString("library work;\n")
+	libs := []string{"work"}
+	clauses := map[string][]string{}
+	for _, p := range pkgs {
+		clauses["work"] = append(clauses["work"], "work."+p+".all")
+	}
+	for _, u := range uses {
+		lib := strings.Split(u, ".")[0]
+		if _, ok := clauses[lib]; !ok {
+			libs = append(libs, lib)
+		}
+		dup := false
+		for _, c := range clauses[lib] {
+			if strings.ToLower(c) == u {
+				dup = true
+			}
+		}
+		if !dup {
+			clauses[lib] = append(clauses[lib], u)
+		}
+	}
+	for i, lib := range libs {
+		if i > 0 {
+			b.WriteString(fmt.Sprintf("\nlibrary %s;\n", lib))
+		}
+		for _, c := range clauses[lib] {
+			b.WriteString(fmt.Sprintf("   use %s;\n", c))
+		}
+	}
+}
+
+func genEnumTestbench(pkg string, e *enum) string {
+	b := strings.Builder{}
+
+	genTestbenchHeader([]string{pkg}, nil, "enumeration", e, &b)
+
+	b.WriteString(
+		fmt.Sprintf(
+			"begin\n\n"+
+				"   main : process is\n"+
+				"   begin\n"+
+				"      for x in %[1]s loop\n"+
+				"         assert %[2]s(to_slv(x)) = x\n"+
+				"            report \"%[2]s(to_slv(x)) round-trip failed for x = \" & %[1]s'image(x)\n"+
+				"            severity failure;\n"+
+				"         assert to_str(x)'length > 0\n"+
+				"            report \"to_str(x) returned empty string for x = \" & %[1]s'image(x)\n"+
+				"            severity failure;\n"+
+				"      end loop;\n\n"+
+				"      std.env.finish;\n"+
+				"      wait;\n"+
+				"   end process;\n\n"+
+				"end architecture;\n",
+			e.name, toTypeFuncName(e.name),
+		),
+	)
+
+	return b.String()
+}
+
+// testbenchRandomTypes returns record and array generables which need random procedures,
+// in the order in which procedures must be declared.
+func testbenchRandomTypes(g gen.Generable, visited map[gen.Generable]bool) []gen.Generable {
+	if visited[g] {
+		return nil
+	}
+	visited[g] = true
+
+	deps := []gen.Generable{}
+	switch g := g.(type) {
+	case *record:
+		for _, f := range g.fields {
+			if f.typGen != nil {
+				deps = append(deps, testbenchRandomTypes(f.typGen, visited)...)
+			}
+		}
+	case *array:
+		if g.elem.typGen != nil {
+			deps = append(deps, testbenchRandomTypes(g.elem.typGen, visited)...)
+		}
+	default:
+		// Enumeration values are drawn inline.
+		return nil
+	}
+
+	return append(deps, g)
+}
+
+// generablePackage returns the name of the package in which the generable is declared.
+func generablePackage(g gen.Generable, pkg string, gens gen.Container) string {
+	for _, lg := range gens {
+		if lg == g {
+			return pkg
+		}
+	}
+
+	pkgs := []string{}
+	for p, tgens := range treeGenerables {
+		for _, tg := range tgens {
+			if tg == g {
+				return p
+			}
+		}
+		if _, ok := tgens.Get(g.Name()); ok {
+			pkgs = append(pkgs, p)
+		}
+	}
+	if len(pkgs) > 0 {
+		sort.Strings(pkgs)
+		return pkgs[0]
+	}
+
+	return pkg
+}
+
+// testbenchRandomSlvWidth returns the width of the random std_logic_vector drawn for the field.
+func testbenchRandomSlvWidth(f field) int {
+	if _, ok := f.typGen.(*enum); ok {
+		// Enumeration value is selected with the modulo of the random index.
+		return 16
+	}
+	return f.width
+}
+
+// genTestbenchRandomField generates statements assigning random value to the target.
+// The slv variable holds random bits drawn for the field.
+func genTestbenchRandomField(f field, target string, slv string, indent string, b *strings.Builder) {
+	switch g := f.typGen.(type) {
+	case *record, *array:
+		b.WriteString(fmt.Sprintf("%srandom(s1, s2, %s);\n", indent, target))
+		return
+	case *enum:
+		b.WriteString(
+			fmt.Sprintf(
+				"%[1]srandom(s1, s2, %[2]s);\n"+
+					"%[1]s%[3]s := %[4]s'val(to_integer(unsigned(%[2]s)) mod (%[4]s'pos(%[4]s'right) + 1));\n",
+				indent, slv, target, g.name,
+			),
+		)
+		return
+	}
+
+	b.WriteString(fmt.Sprintf("%srandom(s1, s2, %s);\n", indent, slv))
+
+	value := ""
+	switch f.typ {
+	case "std_logic", "std_ulogic":
+		value = slv + "(0)"
+	case "bit":
+		value = "to_bit(" + slv + "(0))"
+	case "boolean":
+		value = slv + "(0) = '1'"
+	case "std_logic_vector":
+		value = slv
+	case "std_ulogic_vector", "unsigned", "signed":
+		value = f.typ + "(" + slv + ")"
+	case "integer":
+		value = "to_integer(signed(" + slv + "))"
+	case "natural":
+		value = fmt.Sprintf("to_integer(unsigned(%s(30 downto 0)))", slv)
+	case "positive":
+		value = fmt.Sprintf("maximum(1, to_integer(unsigned(%s(30 downto 0))))", slv)
+	default:
+		// Foreign type, conversion from the std_logic_vector is also checked.
+		funcName := toTypeFuncName(f.typ)
+		if f.toType != "" {
+			funcName = f.toType
+		}
+		value = funcName + "(" + slv + ")"
+	}
+	b.WriteString(fmt.Sprintf("%s%s := %s;\n", indent, target, value))
+}
+
+func genTestbenchRandomRecord(r *record, b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"\n   procedure random(variable s1, s2 : inout positive; variable x : out %s) is\n"+
+				"      variable v : %[1]s;\n",
+			r.name,
+		),
+	)
+	for _, f := range r.fields {
+		if _, ok := f.typGen.(*enum); ok || f.typGen == nil {
+			b.WriteString(
+				fmt.Sprintf(
+					"      variable %s_slv : std_logic_vector(%d downto 0);\n", f.name, testbenchRandomSlvWidth(f)-1,
+				),
+			)
+		}
+	}
+	b.WriteString("   begin\n")
+	for _, f := range r.fields {
+		genTestbenchRandomField(f, "v."+f.name, f.name+"_slv", "      ", b)
+	}
+	b.WriteString("      x := v;\n   end procedure;\n")
+}
+
+func genTestbenchRandomArray(a *array, b *strings.Builder) {
+	b.WriteString(
+		fmt.Sprintf(
+			"\n   procedure random(variable s1, s2 : inout positive; variable x : out %s) is\n"+
+				"      variable v : %[1]s;\n",
+			a.name,
+		),
+	)
+	if _, ok := a.elem.typGen.(*enum); ok || a.elem.typGen == nil {
+		b.WriteString(
+			fmt.Sprintf("      variable slv : std_logic_vector(%d downto 0);\n", testbenchRandomSlvWidth(a.elem)-1),
+		)
+	}
+	b.WriteString(
+		"   begin\n" +
+			"      for i in v'range loop\n",
+	)
+	genTestbenchRandomField(a.elem, "v(i)", "slv", "         ", b)
+	b.WriteString(
+		"      end loop;\n" +
+			"      x := v;\n" +
+			"   end procedure;\n",
+	)
+}
+
+func genRecordTestbench(pkg string, gens gen.Container, uses []string, r *record) string {
+	b := strings.Builder{}
+
+	types := testbenchRandomTypes(r, map[gen.Generable]bool{})
+
+	// Packages declaring field types must also be visible.
+	pkgs := []string{pkg}
+	usedPkgs := map[string]bool{strings.ToLower(pkg): true}
+	addPkg := func(g gen.Generable) {
+		p := generablePackage(g, pkg, gens)
+		if !usedPkgs[strings.ToLower(p)] {
+			usedPkgs[strings.ToLower(p)] = true
+			pkgs = append(pkgs, p)
+		}
+	}
+	for _, t := range types {
+		addPkg(t)
+		var fields []field
+		switch t := t.(type) {
+		case *record:
+			fields = t.fields
+		case *array:
+			fields = []field{t.elem}
+		}
+		for _, f := range fields {
+			if e, ok := f.typGen.(*enum); ok {
+				addPkg(e)
+			}
+		}
+	}
+
+	genTestbenchHeader(pkgs, uses, "record", r, &b)
+
+	b.WriteString(
+		"\n   procedure random(variable s1, s2 : inout positive; variable slv : out std_logic_vector) is\n" +
+			"      variable rand : real;\n" +
+			"   begin\n" +
+			"      for i in slv'range loop\n" +
+			"         uniform(s1, s2, rand);\n" +
+			"         if rand < 0.5 then\n" +
+			"            slv(i) := '0';\n" +
+			"         else\n" +
+			"            slv(i) := '1';\n" +
+			"         end if;\n" +
+			"      end loop;\n" +
+			"   end procedure;\n",
+	)
+
+	for _, t := range types {
+		switch t := t.(type) {
+		case *record:
+			genTestbenchRandomRecord(t, &b)
+		case *array:
+			genTestbenchRandomArray(t, &b)
+		}
+	}
+
+	failReport := "\"round-trip failed in iteration \" & integer'image(i)"
+	if !r.noToStr {
+		failReport = "\"round-trip failed for x = \" & to_str(x, true)"
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"\nbegin\n\n"+
+				"   main : process is\n"+
+				"      variable s1, s2 : positive := 1;\n"+
+				"      variable x : %s;\n"+
+				"   begin\n"+
+				"      for i in 0 to %d loop\n"+
+				"         random(s1, s2, x);\n"+
+				"         assert %s(to_slv(x)) = x\n"+
+				"            report %s\n"+
+				"            severity failure;\n",
+			r.name, testbenchIterations-1, toTypeFuncName(r.name), failReport,
+		),
+	)
+	if !r.noToStr {
+		b.WriteString(
+			"         assert to_str(x)'length > 0\n" +
+				"            report \"to_str(x) returned empty string in iteration \" & integer'image(i)\n" +
+				"            severity failure;\n",
+		)
+	}
+	b.WriteString(
+		"      end loop;\n\n" +
+			"      std.env.finish;\n" +
+			"      wait;\n" +
+			"   end process;\n\n" +
+			"end architecture;\n",
+	)
+
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package vhdl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+)
+
+func TestTestbenchRandomTypes(t *testing.T) {
+	e := &enum{name: "t_state", values: []string{"A", "B"}}
+	arr := &array{name: "t_arr", left: 0, right: 1, elem: field{typ: "t_state", width: 1, typGen: e}}
+	inner := &record{name: "t_inner", fields: []field{{name: "a", typ: "t_arr", typGen: arr}}}
+	outer := &record{
+		name: "t_outer",
+		fields: []field{
+			{name: "x", typ: "t_inner", typGen: inner},
+			{name: "y", typ: "t_inner", typGen: inner},
+			{name: "s", typ: "t_state", typGen: e},
+		},
+	}
+
+	got := testbenchRandomTypes(outer, map[gen.Generable]bool{})
+	want := []gen.Generable{arr, inner, outer}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTestbenchRandomField(t *testing.T) {
+	var tests = []struct {
+		f    field
+		want string
+	}{
+		{field{typ: "std_logic", width: 1}, "random(s1, s2, slv);\nv := slv(0);\n"},
+		{field{typ: "signed", width: 8}, "random(s1, s2, slv);\nv := signed(slv);\n"},
+		{field{typ: "positive", width: 32}, "random(s1, s2, slv);\nv := maximum(1, to_integer(unsigned(slv(30 downto 0))));\n"},
+		{field{typ: "t_foreign", width: 4, toType: "conv"}, "random(s1, s2, slv);\nv := conv(slv);\n"},
+	}
+
+	for i, test := range tests {
+		b := strings.Builder{}
+		genTestbenchRandomField(test.f, "v", "slv", "", &b)
+		if b.String() != test.want {
+			t.Errorf("[%d] got %q, want %q", i, b.String(), test.want)
+		}
+	}
+}
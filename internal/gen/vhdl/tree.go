@@ -0,0 +1,178 @@
+package vhdl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+	"github.com/m-kru/go-thdl/internal/utils"
+)
+
+var useClause *regexp.Regexp = regexp.MustCompile(`(?i)^\s*use\s+(\w+)\.(\w+)\.(all|\w+)\s*;`)
+
+// treeGenerables maps lowercase package names to generables declared in packages
+// within the working directory tree.
+var treeGenerables map[string]gen.Container = map[string]gen.Container{}
+
+// ScanTree scans constants and generables declared in packages within the files.
+// It must be called before the generation, as field widths might depend on them.
+func ScanTree(filepaths []string) {
+	if err := scanTree(filepaths); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+func scanTree(filepaths []string) error {
+	contents := [][]byte{}
+	visited := map[string]bool{}
+	for _, fp := range filepaths {
+		if visited[filepath.Clean(fp)] || utils.IsIgnoredVHDLFile(fp) {
+			continue
+		}
+		visited[filepath.Clean(fp)] = true
+
+		content, err := os.ReadFile(fp)
+		if err != nil {
+			return fmt.Errorf("reading %s: %v", fp, err)
+		}
+		contents = append(contents, content)
+	}
+
+	scanTreeConstants(contents)
+	scanTreeGenerables(contents)
+
+	return nil
+}
+
+var lazyTreeFilepaths func() []string
+var lazyTreeOnce sync.Once
+var lazyTreeErr error
+
+// ScanTreeLazily makes FindEdits scan the tree before the first regeneration of generated code.
+// The tree is not scanned at all if no file contains generated code modified by hand.
+// The filepaths function is called at most once.
+func ScanTreeLazily(filepaths func() []string) {
+	lazyTreeFilepaths = filepaths
+}
+
+// lazyScanTree scans the tree if ScanTreeLazily was called.
+func lazyScanTree() error {
+	if lazyTreeFilepaths == nil {
+		return nil
+	}
+	lazyTreeOnce.Do(func() {
+		lazyTreeErr = scanTree(lazyTreeFilepaths())
+	})
+	return lazyTreeErr
+}
+
+// scanTreeGenerables scans files until no more files can be scanned, as records
+// might depend on generables declared in other files. Files which can't be scanned
+// are skipped, errors are reported when such files are generated.
+func scanTreeGenerables(contents [][]byte) {
+	treeGenerables = map[string]gen.Container{}
+
+	for progress := true; progress; {
+		progress = false
+		pending := [][]byte{}
+		for _, content := range contents {
+			units, err := scanFile(content)
+			if err != nil {
+				pending = append(pending, content)
+				continue
+			}
+			for _, u := range units {
+				if u.typ == "package" {
+					treeGenerables[strings.ToLower(u.name)] = u.gens
+				}
+			}
+			progress = true
+		}
+		contents = pending
+	}
+}
+
+// scanUseClauses returns lowercase suffixes of selected names from use clauses
+// in the file content, for example 'pkg.all' or 'pkg.t_status'.
+func scanUseClauses(content []byte) []string {
+	uses := []string{}
+
+	sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader(content))}
+	for sCtx.scan() {
+		if sm := useClause.FindSubmatch(sCtx.line); len(sm) > 0 {
+			uses = append(uses, strings.ToLower(string(sm[2])+"."+string(sm[3])))
+		}
+	}
+
+	return uses
+}
+
+// scanLibraryUseClauses returns lowercase selected names, including the library,
+// from use clauses of the content. Use clauses of the ieee and std libraries are skipped.
+func scanLibraryUseClauses(content []byte) []string {
+	uses := []string{}
+
+	sCtx := scanContext{scanner: bufio.NewScanner(bytes.NewReader(content))}
+	for sCtx.scan() {
+		if sm := useClause.FindSubmatch(sCtx.line); len(sm) > 0 {
+			lib := strings.ToLower(string(sm[1]))
+			if lib == "ieee" || lib == "std" {
+				continue
+			}
+			uses = append(uses, lib+"."+strings.ToLower(string(sm[2])+"."+string(sm[3])))
+		}
+	}
+
+	return uses
+}
+
+// lookupTreeGenerable looks for the generable declared in other package.
+// If the generable is declared in multiple packages, then use clauses of the
+// scanned file are used for disambiguation. Nil is returned if generable is not found.
+func (sc *scanContext) lookupTreeGenerable(typ string) (gen.Generable, error) {
+	used := map[string]bool{}
+	for _, u := range sc.uses {
+		used[u] = true
+	}
+
+	all := []string{}
+	visible := []string{}
+	for pkg, gens := range treeGenerables {
+		if _, ok := gens.Get(typ); ok {
+			all = append(all, pkg)
+			if used[pkg+".all"] || used[pkg+"."+typ] {
+				visible = append(visible, pkg)
+			}
+		}
+	}
+
+	var pkgs []string
+	if len(visible) > 0 {
+		pkgs = visible
+	} else {
+		pkgs = all
+	}
+
+	switch len(pkgs) {
+	case 0:
+		return nil, nil
+	case 1:
+		gens := treeGenerables[pkgs[0]]
+		g, _ := gens.Get(typ)
+		return g, nil
+	default:
+		sort.Strings(pkgs)
+		return nil, fmt.Errorf(
+			"type '%s' is declared in multiple packages: %s, add use clause to disambiguate",
+			typ, strings.Join(pkgs, ", "),
+		)
+	}
+}
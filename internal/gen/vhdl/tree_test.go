@@ -0,0 +1,70 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestLookupTreeGenerable(t *testing.T) {
+	contents := [][]byte{
+		[]byte(`package a is
+   --thdl:gen encoding=one-hot
+   type t_status is (OK, ERR, BUSY);
+end package;`),
+		[]byte(`package b is
+   --thdl:gen
+   type t_status is (OK, ERR, BUSY);
+end package;`),
+		// Depends on the package declared in the next file.
+		[]byte(`use work.d.all;
+package c is
+   --thdl:gen
+   type t_cmd is record
+      mode : t_mode;
+   end record;
+end package;`),
+		[]byte(`package d is
+   --thdl:gen encoding=one-hot
+   type t_mode is (READ, WRITE);
+end package;`),
+	}
+
+	scanTreeGenerables(contents)
+	defer func() { scanTreeGenerables(nil) }()
+
+	var tests = []struct {
+		uses  []string
+		typ   string
+		width int
+		err   string
+	}{
+		{uses: []string{"a.all"}, typ: "t_status", width: 3},
+		{uses: []string{"b.t_status"}, typ: "t_status", width: 2},
+		{uses: []string{"a.all", "b.all"}, typ: "t_status",
+			err: "type 't_status' is declared in multiple packages: a, b, add use clause to disambiguate"},
+		{typ: "t_status",
+			err: "type 't_status' is declared in multiple packages: a, b, add use clause to disambiguate"},
+		{typ: "t_mode", width: 2},
+		{typ: "t_cmd", width: 2},
+		{typ: "t_unknown"},
+	}
+
+	for i, test := range tests {
+		sCtx := scanContext{uses: test.uses}
+		g, err := sCtx.lookupTreeGenerable(test.typ)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d]: %v", i, err)
+		} else if test.width == 0 {
+			if g != nil {
+				t.Errorf("[%d]: got generable %s, want nil", i, g.Name())
+			}
+		} else if g == nil || g.Width() != test.width {
+			t.Errorf("[%d]: got %v, want generable with width %d", i, g, test.width)
+		}
+	}
+}
@@ -14,4 +14,6 @@ type unit struct {
 	lineNum uint
 	typ     string
 	gens    gen.Container
+	// Use clauses of the file, except ieee and std ones, for example 'work.pkg.all'.
+	uses []string
 }
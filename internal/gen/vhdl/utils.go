@@ -24,3 +24,12 @@ func funcParamName(typeName string) string {
 	}
 	return name
 }
+
+// constNamePrefix returns the prefix of names of constants generated for particular type.
+func constNamePrefix(typeName string) string {
+	name := typeName
+	if strings.HasPrefix(name, "t_") {
+		name = name[2:]
+	}
+	return "C_" + strings.ToUpper(name)
+}
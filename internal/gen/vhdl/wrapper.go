@@ -0,0 +1,259 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/vhdl"
+)
+
+var entityDeclaration *regexp.Regexp = regexp.MustCompile(`(?i)\bentity\s+(\w+)\s+is\b`)
+var entityEnd *regexp.Regexp = regexp.MustCompile(`(?i)\bend\s*(entity\s*)?(\w+\s*)?;`)
+
+// wrapperPort is a port of the wrapped entity.
+type wrapperPort struct {
+	vhdl.InterfaceElement
+	// Generated record of the port type, nil if the port is not flattened.
+	rec *record
+	// Package declaring the record type.
+	pkg string
+}
+
+// genWrappers generates wrapper for each entity with ports of generated record types.
+// Records are flattened into std_logic_vector ports with the same bit layout as in to_slv.
+// Wrappers are named after entities with the '_wrapper' suffix.
+func genWrappers(fileContent []byte) error {
+	content := vhdl.StripComments(string(fileContent))
+	sc := scanContext{uses: scanUseClauses(fileContent)}
+
+	for _, loc := range entityDeclaration.FindAllStringIndex(content, -1) {
+		code := content[loc[0]:]
+		if end := entityEnd.FindStringIndex(code); end != nil {
+			code = code[:end[1]]
+		}
+
+		ent, err := vhdl.ParseEntity(code)
+		if err != nil {
+			return fmt.Errorf("entity: %v", err)
+		}
+
+		ports, err := wrapperPorts(ent, &sc)
+		if err != nil {
+			return fmt.Errorf("entity %s: %v", ent.Name, err)
+		}
+		if ports == nil {
+			continue
+		}
+
+		path := filepath.Join(genArgs.WrapperDir, ent.Name+"_wrapper.vhd")
+		err = emitFile(path, []byte(genWrapper(ent, ports, scanLibraryUseClauses(fileContent))))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wrapperPorts returns ports of the entity with generated records of port types.
+// Nil is returned if none of the ports is of generated record type.
+func wrapperPorts(ent vhdl.Entity, sc *scanContext) ([]wrapperPort, error) {
+	ports := []wrapperPort{}
+	flattened := false
+
+	for _, p := range ent.Ports {
+		// Type might be a selected name, for example 'work.pkg.t_cfg'.
+		names := strings.Split(strings.ToLower(p.Type), ".")
+		typ := names[len(names)-1]
+
+		g, err := sc.lookupTreeGenerable(typ)
+		if err != nil {
+			return nil, fmt.Errorf("port '%s': %v", p.Name, err)
+		}
+
+		wp := wrapperPort{InterfaceElement: p}
+		if rec, ok := g.(*record); ok {
+			if p.Mode != "in" && p.Mode != "out" {
+				return nil, fmt.Errorf("port '%s': %s ports of record type can't be flattened", p.Name, p.Mode)
+			}
+			wp.rec = rec
+			wp.pkg = generablePackage(rec, "", nil)
+			if wp.pkg == "" {
+				return nil, fmt.Errorf("port '%s': can't resolve package declaring type '%s'", p.Name, rec.name)
+			}
+			flattened = true
+		}
+		ports = append(ports, wp)
+	}
+
+	if !flattened {
+		return nil, nil
+	}
+
+	// Internal record signals must not collide with ports and generics.
+	names := map[string]bool{}
+	for _, g := range ent.Generics {
+		names[strings.ToLower(g.Name)] = true
+	}
+	for _, p := range ent.Ports {
+		names[strings.ToLower(p.Name)] = true
+	}
+	for _, p := range ports {
+		if p.rec != nil && names[strings.ToLower(recSignalName(p))] {
+			return nil, fmt.Errorf(
+				"port '%s': internal signal '%s' collides with port or generic name", p.Name, recSignalName(p),
+			)
+		}
+	}
+
+	return ports, nil
+}
+
+// recSignalName returns the name of the internal signal of the flattened port record type.
+func recSignalName(p wrapperPort) string {
+	return p.Name + "_rec"
+}
+
+func maxPortNameLen(ports []wrapperPort) int {
+	l := 0
+	for _, p := range ports {
+		if len(p.Name) > l {
+			l = len(p.Name)
+		}
+	}
+	return l
+}
+
+func genWrapper(ent vhdl.Entity, ports []wrapperPort, uses []string) string {
+	b := strings.Builder{}
+
+	// Packages declaring record types must be visible.
+	pkgs := []string{}
+	usedPkgs := map[string]bool{}
+	for _, p := range ports {
+		if p.rec == nil {
+			continue
+		}
+		if !usedPkgs[p.pkg] {
+			usedPkgs[p.pkg] = true
+			pkgs = append(pkgs, p.pkg)
+		}
+	}
+
+	b.WriteString(
+		fmt.Sprintf(
+			"-- Below code was automatically generated with the thdl tool.\n"+
+				"-- Do not modify it by hand, unless you really know what you do.\n"+
+				"-- More info on https://github.com/m-kru/go-thdl.\n"+
+				"--\n"+
+				"-- Source: VHDL entity '%s'.\n\n"+
+				"library ieee;\n"+
+				"   use ieee.std_logic_1164.all;\n\n",
+			ent.Name,
+		),
+	)
+	genUseClauses(pkgs, uses, &b)
+
+	name := ent.Name + "_wrapper"
+	b.WriteString(fmt.Sprintf("\nentity %s is\n", name))
+
+	if len(ent.Generics) > 0 {
+		b.WriteString("   generic (\n")
+		nameLen := 0
+		for _, g := range ent.Generics {
+			if g.Decl == "" && len(g.Name) > nameLen {
+				nameLen = len(g.Name)
+			}
+		}
+		for i, g := range ent.Generics {
+			sep := ";"
+			if i == len(ent.Generics)-1 {
+				sep = ""
+			}
+			if g.Decl != "" {
+				b.WriteString(fmt.Sprintf("      %s%s\n", g.Decl, sep))
+			} else {
+				b.WriteString(fmt.Sprintf("      %-*s : %s%s\n", nameLen, g.Name, g.Declaration(), sep))
+			}
+		}
+		b.WriteString("   );\n")
+	}
+
+	nameLen := maxPortNameLen(ports)
+	b.WriteString("   port (\n")
+	for i, p := range ports {
+		sep := ";"
+		if i == len(ports)-1 {
+			sep = ""
+		}
+		decl := p.Declaration()
+		if p.rec != nil {
+			decl = fmt.Sprintf("%s std_logic_vector(%d downto 0)", p.Mode, p.rec.Width()-1)
+		}
+		b.WriteString(fmt.Sprintf("      %-*s : %s%s\n", nameLen, p.Name, decl, sep))
+	}
+	b.WriteString("   );\nend entity;\n\n")
+
+	b.WriteString(fmt.Sprintf("architecture wrapper of %s is\n\n", name))
+
+	recNameLen := 0
+	for _, p := range ports {
+		if p.rec != nil && len(recSignalName(p)) > recNameLen {
+			recNameLen = len(recSignalName(p))
+		}
+	}
+	for _, p := range ports {
+		if p.rec != nil {
+			b.WriteString(fmt.Sprintf("   signal %-*s : %s;\n", recNameLen, recSignalName(p), p.rec.name))
+		}
+	}
+
+	b.WriteString("\nbegin\n\n")
+
+	for _, p := range ports {
+		if p.rec == nil {
+			continue
+		}
+		if p.Mode == "in" {
+			b.WriteString(fmt.Sprintf("   %s <= %s(%s);\n", recSignalName(p), toTypeFuncName(p.rec.name), p.Name))
+		} else {
+			b.WriteString(fmt.Sprintf("   %s <= to_slv(%s);\n", p.Name, recSignalName(p)))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n   u_%s : entity work.%[1]s\n", ent.Name))
+	if len(ent.Generics) > 0 {
+		b.WriteString("      generic map (\n")
+		nameLen := 0
+		for _, g := range ent.Generics {
+			if len(g.Name) > nameLen {
+				nameLen = len(g.Name)
+			}
+		}
+		for i, g := range ent.Generics {
+			sep := ","
+			if i == len(ent.Generics)-1 {
+				sep = ""
+			}
+			b.WriteString(fmt.Sprintf("         %-*s => %s%s\n", nameLen, g.Name, g.Name, sep))
+		}
+		b.WriteString("      )\n")
+	}
+	b.WriteString("      port map (\n")
+	for i, p := range ports {
+		sep := ","
+		if i == len(ports)-1 {
+			sep = ""
+		}
+		actual := p.Name
+		if p.rec != nil {
+			actual = recSignalName(p)
+		}
+		b.WriteString(fmt.Sprintf("         %-*s => %s%s\n", nameLen, p.Name, actual, sep))
+	}
+	b.WriteString("      );\n\nend architecture;\n")
+
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package vhdl
+
+import (
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/gen/gen"
+	"github.com/m-kru/go-thdl/internal/vhdl"
+)
+
+func TestWrapperPorts(t *testing.T) {
+	cfg := &record{name: "t_cfg", fields: []field{{name: "en", typ: "std_logic", width: 1}}}
+	treeGenerables = map[string]gen.Container{"pkg": {cfg}}
+	defer func() { treeGenerables = map[string]gen.Container{} }()
+
+	sc := scanContext{}
+
+	ent := vhdl.Entity{
+		Name: "core",
+		Ports: []vhdl.InterfaceElement{
+			{Name: "clk_i", Mode: "in", Type: "std_logic"},
+			{Name: "cfg_i", Mode: "in", Type: "work.pkg.T_CFG"},
+		},
+	}
+	ports, err := wrapperPorts(ent, &sc)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(ports) != 2 || ports[0].rec != nil || ports[1].rec != cfg {
+		t.Errorf("invalid ports: %+v", ports)
+	}
+
+	ent.Ports = ent.Ports[:1]
+	if ports, _ := wrapperPorts(ent, &sc); ports != nil {
+		t.Errorf("entity without record ports should not be wrapped, got %+v", ports)
+	}
+
+	ent.Ports = []vhdl.InterfaceElement{{Name: "cfg_io", Mode: "inout", Type: "t_cfg"}}
+	if _, err := wrapperPorts(ent, &sc); err == nil {
+		t.Errorf("expected error for inout record port")
+	}
+
+	ent.Ports = []vhdl.InterfaceElement{
+		{Name: "cfg", Mode: "in", Type: "t_cfg"},
+		{Name: "CFG_REC", Mode: "in", Type: "std_logic"},
+	}
+	if _, err := wrapperPorts(ent, &sc); err == nil {
+		t.Errorf("expected error for internal signal name collision")
+	}
+}
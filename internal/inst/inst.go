@@ -0,0 +1,176 @@
+package inst
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/args"
+	"github.com/m-kru/go-thdl/internal/doc"
+	vhdldoc "github.com/m-kru/go-thdl/internal/doc/vhdl"
+	"github.com/m-kru/go-thdl/internal/vhdl"
+)
+
+func Inst(args args.InstArgs) {
+	ent, lib, _ := findEntity(args.DocArgs, args.EntityPath)
+	fmt.Print(template(ent, lib, args.Component, args.Signals))
+}
+
+// findEntity looks up and parses the entity matching the path.
+// It returns the entity, the name of its library and its symbol.
+func findEntity(docArgs args.DocArgs, entityPath string) (vhdl.Entity, string, vhdldoc.Entity) {
+	doc.Scan(docArgs)
+
+	// Paths of entities are unique, the same entity might be found via multiple symbol paths.
+	ents := map[string]vhdldoc.Entity{}
+	for _, syms := range doc.Lookup(entityPath) {
+		for _, s := range syms {
+			if e, ok := s.(vhdldoc.Entity); ok {
+				ents[e.Path()] = e
+			}
+		}
+	}
+
+	if len(ents) == 0 {
+		log.Fatalf("found no entity matching path '%s'", entityPath)
+	} else if len(ents) > 1 {
+		paths := []string{}
+		for p := range ents {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		log.Fatalf(
+			"provided path is ambiguous, found entities with following paths:\n  %s",
+			strings.Join(paths, "\n  "),
+		)
+	}
+
+	var ent vhdl.Entity
+	var lib string
+	var sym vhdldoc.Entity
+	for path, e := range ents {
+		var err error
+		ent, err = vhdl.ParseEntity(e.Code())
+		if err != nil {
+			log.Fatalf("%s: entity %s: %v", e.Filepath(), e.Name(), err)
+		}
+
+		// Path has form 'vhdl:lib.entity'.
+		lib = strings.Split(strings.TrimPrefix(path, "vhdl:"), ".")[0]
+		sym = e
+	}
+
+	return ent, lib, sym
+}
+
+// template returns the instantiation, optionally preceded by the component
+// declaration and signal declarations.
+func template(ent vhdl.Entity, lib string, component bool, signals bool) string {
+	parts := []string{}
+
+	if component {
+		parts = append(parts, componentDeclaration(ent))
+	}
+	if signals && len(ent.Ports) > 0 {
+		parts = append(parts, signalDeclarations(ent))
+	}
+	parts = append(parts, instantiation(ent, lib, component))
+
+	return strings.Join(parts, "\n")
+}
+
+func maxNameLen(elems []vhdl.InterfaceElement) int {
+	l := 0
+	for _, e := range elems {
+		if len(e.Name) > l {
+			l = len(e.Name)
+		}
+	}
+	return l
+}
+
+// interfaceList writes the generic or port clause of the entity or component declaration.
+func interfaceList(keyword string, elems []vhdl.InterfaceElement, b *strings.Builder) {
+	if len(elems) == 0 {
+		return
+	}
+	nameLen := maxNameLen(elems)
+	b.WriteString(fmt.Sprintf("   %s (\n", keyword))
+	for i, e := range elems {
+		sep := ";"
+		if i == len(elems)-1 {
+			sep = ""
+		}
+		if e.Decl != "" {
+			b.WriteString(fmt.Sprintf("      %s%s\n", e.Decl, sep))
+		} else {
+			b.WriteString(fmt.Sprintf("      %-*s : %s%s\n", nameLen, e.Name, e.Declaration(), sep))
+		}
+	}
+	b.WriteString("   );\n")
+}
+
+func componentDeclaration(ent vhdl.Entity) string {
+	b := strings.Builder{}
+
+	b.WriteString(fmt.Sprintf("component %s is\n", ent.Name))
+
+	interfaceList("generic", ent.Generics, &b)
+	interfaceList("port", ent.Ports, &b)
+
+	b.WriteString("end component;\n")
+
+	return b.String()
+}
+
+func signalDeclarations(ent vhdl.Entity) string {
+	b := strings.Builder{}
+
+	nameLen := maxNameLen(ent.Ports)
+	for _, p := range ent.Ports {
+		b.WriteString(fmt.Sprintf("signal %-*s : %s;\n", nameLen, p.Name, p.Type))
+	}
+
+	return b.String()
+}
+
+func instantiation(ent vhdl.Entity, lib string, component bool) string {
+	b := strings.Builder{}
+
+	if component {
+		b.WriteString(fmt.Sprintf("u_%s : component %s\n", ent.Name, ent.Name))
+	} else {
+		b.WriteString(fmt.Sprintf("u_%s : entity %s.%s\n", ent.Name, lib, ent.Name))
+	}
+
+	associations := func(keyword string, elems []vhdl.InterfaceElement, last bool) {
+		if len(elems) == 0 {
+			return
+		}
+		nameLen := maxNameLen(elems)
+		b.WriteString(fmt.Sprintf("   %s map (\n", keyword))
+		for i, e := range elems {
+			actual := e.Name + ","
+			if i == len(elems)-1 {
+				actual = e.Name + " "
+			}
+			b.WriteString(fmt.Sprintf("      %-*s => %-*s -- %s\n", nameLen, e.Name, nameLen+1, actual, e.Declaration()))
+		}
+		if last {
+			b.WriteString("   );\n")
+		} else {
+			b.WriteString("   )\n")
+		}
+	}
+	associations("generic", ent.Generics, len(ent.Ports) == 0)
+	associations("port", ent.Ports, true)
+
+	if len(ent.Generics) == 0 && len(ent.Ports) == 0 {
+		// Remove the trailing newline and terminate the statement.
+		s := strings.TrimSuffix(b.String(), "\n")
+		return s + ";\n"
+	}
+
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package inst
+
+import (
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/vhdl"
+)
+
+const fifoCode = `entity fifo is
+   generic (
+      WIDTH : positive := 8; -- Data width
+      DEPTH : positive := 16
+   );
+   port (
+      clk_i, rst_i : in std_logic;
+      data_i : in  std_logic_vector(WIDTH - 1 downto 0);
+      full_o : out std_logic
+   );
+end entity;`
+
+func TestTemplate(t *testing.T) {
+	ent, _ := vhdl.ParseEntity(fifoCode)
+
+	want := `u_fifo : entity lib.fifo
+   generic map (
+      WIDTH => WIDTH, -- positive := 8
+      DEPTH => DEPTH  -- positive := 16
+   )
+   port map (
+      clk_i  => clk_i,  -- in std_logic
+      rst_i  => rst_i,  -- in std_logic
+      data_i => data_i, -- in std_logic_vector(WIDTH - 1 downto 0)
+      full_o => full_o  -- out std_logic
+   );
+`
+	if got := template(ent, "lib", false, false); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = `component fifo is
+   generic (
+      WIDTH : positive := 8;
+      DEPTH : positive := 16
+   );
+   port (
+      clk_i  : in std_logic;
+      rst_i  : in std_logic;
+      data_i : in std_logic_vector(WIDTH - 1 downto 0);
+      full_o : out std_logic
+   );
+end component;
+
+signal clk_i  : std_logic;
+signal rst_i  : std_logic;
+signal data_i : std_logic_vector(WIDTH - 1 downto 0);
+signal full_o : std_logic;
+
+u_fifo : component fifo
+`
+	if got := template(ent, "lib", true, true); got[:len(want)] != want {
+		t.Errorf("\ngot:\n%s\nwant prefix:\n%s", got, want)
+	}
+
+	if got := template(vhdl.Entity{Name: "empty"}, "work", false, false); got != "u_empty : entity work.empty;\n" {
+		t.Errorf("got %q", got)
+	}
+}
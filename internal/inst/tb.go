@@ -0,0 +1,313 @@
+package inst
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/args"
+	"github.com/m-kru/go-thdl/internal/vhdl"
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
+
+// Reset port names must start with the reset pattern, so that names such as 'first_i' are not detected.
+var positiveResetPortRegexp *regexp.Regexp = regexp.MustCompile(`(^|_)a?` + re.PositiveResetPattern + `$`)
+var negativeResetPortRegexp *regexp.Regexp = regexp.MustCompile(`(^|_)a?` + re.NegativeResetPattern + `$`)
+
+var contextItem *regexp.Regexp = regexp.MustCompile(`(?i)\b(library|use|context)\s+([\w.,\s]+);`)
+
+// Number of clock periods the reset is asserted for.
+const tbResetCycles = 10
+
+type portKind int
+
+const (
+	dataPortKind portKind = iota
+	clockPortKind
+	positiveResetPortKind
+	negativeResetPortKind
+)
+
+// kind returns the kind of the port detected with clock and reset naming heuristics.
+func kind(ie vhdl.InterfaceElement) portKind {
+	if ie.Mode != "in" || !vhdl.IsSingleBitStdType(strings.ToLower(ie.Type)) {
+		return dataPortKind
+	}
+
+	name := strings.ToLower(ie.Name)
+	if re.ClockPort.MatchString(name) {
+		return clockPortKind
+	} else if negativeResetPortRegexp.MatchString(name) {
+		return negativeResetPortKind
+	} else if positiveResetPortRegexp.MatchString(name) {
+		return positiveResetPortKind
+	}
+
+	return dataPortKind
+}
+
+// literal returns the literal of the single bit type.
+func literal(ie vhdl.InterfaceElement, val bool) string {
+	if strings.ToLower(ie.Type) == "boolean" {
+		if val {
+			return "true"
+		}
+		return "false"
+	}
+	if val {
+		return "'1'"
+	}
+	return "'0'"
+}
+
+// clockPeriodName returns the name of the clock period constant.
+func clockPeriodName(clk vhdl.InterfaceElement) string {
+	name := strings.ToLower(clk.Name)
+	name = strings.TrimSuffix(name, "_i")
+	return strings.ToUpper(name) + "_PERIOD"
+}
+
+// clockPeriod returns the clock period. The frequency in MHz is taken from the clock name
+// if present, for example 'clk100_i', otherwise 100 MHz is assumed.
+func clockPeriod(clk vhdl.InterfaceElement) string {
+	sm := re.ClockFrequency.FindStringSubmatch(strings.ToLower(clk.Name))
+	if sm == nil || strings.Trim(sm[2], "0") == "" {
+		return "10 ns"
+	}
+	return "1 us / " + strings.TrimLeft(sm[2], "0")
+}
+
+func Tb(args args.TbArgs) {
+	ent, lib, sym := findEntity(args.DocArgs, args.EntityPath)
+
+	src, err := os.ReadFile(sym.Filepath())
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	content := testbench(ent, lib, sourceContext(string(src), ent.Name), args.Harness)
+
+	if args.ToStdout {
+		fmt.Print(content)
+		return
+	}
+
+	path := "tb_" + ent.Name + ".vhd"
+	if _, err := os.Stat(path); err == nil {
+		log.Fatalf("%s already exists, remove it or use -to-stdout flag", path)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// sourceContext returns library, use and context clauses preceding the entity declaration.
+func sourceContext(src string, entName string) []string {
+	src = vhdl.StripComments(src)
+
+	entDecl := regexp.MustCompile(`(?i)\bentity\s+` + entName + `\s+is\b`)
+	if loc := entDecl.FindStringIndex(src); loc != nil {
+		src = src[:loc[0]]
+	}
+
+	items := []string{}
+	for _, sm := range contextItem.FindAllStringSubmatch(src, -1) {
+		for _, name := range strings.Split(sm[2], ",") {
+			items = append(items, strings.ToLower(sm[1])+" "+vhdl.Normalize(name))
+		}
+	}
+
+	return items
+}
+
+// contextClause returns the testbench context clause. Clauses of the entity source file
+// are included, as they might be required for port and generic types.
+func contextClause(srcContext []string, lib string, harness string) string {
+	items := []string{"library ieee", "use ieee.std_logic_1164.all"}
+	if lib != "work" {
+		items = append(items, "library "+lib)
+	}
+	items = append(items, srcContext...)
+	switch harness {
+	case "osvvm":
+		items = append(items, "library osvvm", "context osvvm.OsvvmContext")
+	case "vunit":
+		items = append(items, "library vunit_lib", "context vunit_lib.vunit_context")
+	}
+
+	// Group use and context clauses by libraries in order of appearance.
+	libs := []string{}
+	clauses := map[string][]string{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		if seen[strings.ToLower(item)] {
+			continue
+		}
+		seen[strings.ToLower(item)] = true
+
+		words := strings.SplitN(item, " ", 2)
+		name := strings.ToLower(strings.Split(words[1], ".")[0])
+		if _, ok := clauses[name]; !ok {
+			libs = append(libs, name)
+			clauses[name] = []string{}
+		}
+		if words[0] != "library" {
+			clauses[name] = append(clauses[name], item)
+		}
+	}
+
+	b := strings.Builder{}
+	for i, l := range libs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if l != "std" {
+			b.WriteString(fmt.Sprintf("library %s;\n", l))
+		}
+		for _, c := range clauses[l] {
+			b.WriteString(fmt.Sprintf("   %s;\n", c))
+		}
+	}
+
+	return b.String()
+}
+
+// testbench returns the testbench skeleton of the entity.
+func testbench(ent vhdl.Entity, lib string, srcContext []string, harness string) string {
+	b := strings.Builder{}
+
+	name := "tb_" + ent.Name
+
+	b.WriteString(contextClause(srcContext, lib, harness))
+
+	// Generics of the testbench mirror generics of the entity,
+	// so that they can be set from the simulator command line.
+	generics := ent.Generics
+	if harness == "vunit" {
+		generics = append(append([]vhdl.InterfaceElement{}, generics...), vhdl.InterfaceElement{Name: "runner_cfg", Type: "string"})
+	}
+	b.WriteString(fmt.Sprintf("\nentity %s is\n", name))
+	interfaceList("generic", generics, &b)
+	b.WriteString("end entity;\n\n")
+
+	b.WriteString(fmt.Sprintf("architecture test of %s is\n\n", name))
+
+	clocks := []vhdl.InterfaceElement{}
+	resets := []vhdl.InterfaceElement{}
+	for _, p := range ent.Ports {
+		switch kind(p) {
+		case clockPortKind:
+			clocks = append(clocks, p)
+		case positiveResetPortKind, negativeResetPortKind:
+			resets = append(resets, p)
+		}
+	}
+
+	if len(clocks) > 0 {
+		nameLen := 0
+		for _, c := range clocks {
+			if len(clockPeriodName(c)) > nameLen {
+				nameLen = len(clockPeriodName(c))
+			}
+		}
+		for _, c := range clocks {
+			b.WriteString(fmt.Sprintf("   constant %-*s : time := %s;\n", nameLen, clockPeriodName(c), clockPeriod(c)))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(ent.Ports) > 0 {
+		nameLen := maxNameLen(ent.Ports)
+		for _, p := range ent.Ports {
+			init := ""
+			switch kind(p) {
+			case clockPortKind, negativeResetPortKind:
+				init = " := " + literal(p, false)
+			case positiveResetPortKind:
+				init = " := " + literal(p, true)
+			default:
+				if p.Default != "" {
+					init = " := " + p.Default
+				}
+			}
+			b.WriteString(fmt.Sprintf("   signal %-*s : %s%s;\n", nameLen, p.Name, p.Type, init))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("begin\n\n")
+
+	for _, c := range clocks {
+		b.WriteString(fmt.Sprintf("   %s <= not %[1]s after %s / 2;\n", c.Name, clockPeriodName(c)))
+	}
+	if len(clocks) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(instantiation(ent, lib, false), "\n"), "\n") {
+		b.WriteString("   " + line + "\n")
+	}
+
+	b.WriteString("\n   main : process is\n   begin\n")
+
+	indent := "      "
+	switch harness {
+	case "osvvm":
+		b.WriteString(fmt.Sprintf("      SetTestName(\"%s\");\n\n", name))
+	case "vunit":
+		b.WriteString("      test_runner_setup(runner, runner_cfg);\n\n")
+	}
+
+	if len(resets) > 0 {
+		b.WriteString("      -- Reset sequence.\n")
+		if len(clocks) > 0 {
+			b.WriteString(fmt.Sprintf("      wait for %d * %s;\n", tbResetCycles, clockPeriodName(clocks[0])))
+		} else {
+			b.WriteString(fmt.Sprintf("      wait for %d * 10 ns;\n", tbResetCycles))
+		}
+		for _, r := range resets {
+			b.WriteString(fmt.Sprintf("      %s <= %s;\n", r.Name, literal(r, kind(r) == negativeResetPortKind)))
+		}
+		if len(clocks) > 0 {
+			b.WriteString(fmt.Sprintf("      wait until rising_edge(%s);\n", clocks[0].Name))
+		}
+		b.WriteString("\n")
+	}
+
+	if harness == "vunit" {
+		b.WriteString(
+			"      while test_suite loop\n" +
+				"         if run(\"test\") then\n",
+		)
+		indent = "            "
+	}
+
+	b.WriteString(indent + "-- Stimulus.\n")
+
+	switch harness {
+	case "osvvm":
+		b.WriteString(
+			"\n      EndOfTestReports;\n" +
+				"      std.env.stop;\n" +
+				"      wait;\n",
+		)
+	case "vunit":
+		b.WriteString(
+			"         end if;\n" +
+				"      end loop;\n\n" +
+				"      test_runner_cleanup(runner);\n" +
+				"      wait;\n",
+		)
+	default:
+		b.WriteString(
+			"\n      std.env.finish;\n" +
+				"      wait;\n",
+		)
+	}
+
+	b.WriteString("   end process;\n\nend architecture;\n")
+
+	return b.String()
+}
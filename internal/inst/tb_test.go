@@ -0,0 +1,158 @@
+package inst
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/m-kru/go-thdl/internal/vhdl"
+)
+
+func TestPortKind(t *testing.T) {
+	var tests = []struct {
+		port vhdl.InterfaceElement
+		want portKind
+	}{
+		{vhdl.InterfaceElement{Name: "clk_i", Mode: "in", Type: "std_logic"}, clockPortKind},
+		{vhdl.InterfaceElement{Name: "Sys_Clk_i", Mode: "in", Type: "std_ulogic"}, clockPortKind},
+		{vhdl.InterfaceElement{Name: "clk100_i", Mode: "in", Type: "std_logic"}, clockPortKind},
+		{vhdl.InterfaceElement{Name: "aclk", Mode: "in", Type: "std_logic"}, clockPortKind},
+		{vhdl.InterfaceElement{Name: "clk_en_i", Mode: "in", Type: "std_logic"}, dataPortKind},
+		{vhdl.InterfaceElement{Name: "clk_o", Mode: "out", Type: "std_logic"}, dataPortKind},
+		{vhdl.InterfaceElement{Name: "clk_i", Mode: "in", Type: "std_logic_vector(1 downto 0)"}, dataPortKind},
+		{vhdl.InterfaceElement{Name: "rst_i", Mode: "in", Type: "std_logic"}, positiveResetPortKind},
+		{vhdl.InterfaceElement{Name: "reset", Mode: "in", Type: "boolean"}, positiveResetPortKind},
+		{vhdl.InterfaceElement{Name: "rstn_i", Mode: "in", Type: "std_logic"}, negativeResetPortKind},
+		{vhdl.InterfaceElement{Name: "sys_arst_n_i", Mode: "in", Type: "std_logic"}, negativeResetPortKind},
+		{vhdl.InterfaceElement{Name: "first_i", Mode: "in", Type: "std_logic"}, dataPortKind},
+	}
+
+	for i, test := range tests {
+		if got := kind(test.port); got != test.want {
+			t.Errorf("[%d] %s: got %d, want %d", i, test.port.Name, got, test.want)
+		}
+	}
+}
+
+func TestClockPeriod(t *testing.T) {
+	var tests = []struct {
+		name       string
+		wantName   string
+		wantPeriod string
+	}{
+		{"clk_i", "CLK_PERIOD", "10 ns"},
+		{"sys_clk_i", "SYS_CLK_PERIOD", "10 ns"},
+		{"clk_125_i", "CLK_125_PERIOD", "1 us / 125"},
+		{"clk0", "CLK0_PERIOD", "10 ns"},
+	}
+
+	for i, test := range tests {
+		clk := vhdl.InterfaceElement{Name: test.name, Mode: "in", Type: "std_logic"}
+		if got := clockPeriodName(clk); got != test.wantName {
+			t.Errorf("[%d] name: got %s, want %s", i, got, test.wantName)
+		}
+		if got := clockPeriod(clk); got != test.wantPeriod {
+			t.Errorf("[%d] period: got %s, want %s", i, got, test.wantPeriod)
+		}
+	}
+}
+
+func TestSourceContext(t *testing.T) {
+	src := `library ieee;
+   use ieee.std_logic_1164.all; -- use foo.bar.all;
+library lib; use lib.a_pkg.all, lib.b_pkg.all;
+entity fifo is
+end entity;
+use lib.c_pkg.all;
+`
+	want := []string{
+		"library ieee",
+		"use ieee.std_logic_1164.all",
+		"library lib",
+		"use lib.a_pkg.all",
+		"use lib.b_pkg.all",
+	}
+
+	got := sourceContext(src, "fifo")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestTestbench(t *testing.T) {
+	ent, _ := vhdl.ParseEntity(fifoCode)
+
+	want := `library ieee;
+   use ieee.std_logic_1164.all;
+
+library lib;
+
+entity tb_fifo is
+   generic (
+      WIDTH : positive := 8;
+      DEPTH : positive := 16
+   );
+end entity;
+
+architecture test of tb_fifo is
+
+   constant CLK_PERIOD : time := 10 ns;
+
+   signal clk_i  : std_logic := '0';
+   signal rst_i  : std_logic := '1';
+   signal data_i : std_logic_vector(WIDTH - 1 downto 0);
+   signal full_o : std_logic;
+
+begin
+
+   clk_i <= not clk_i after CLK_PERIOD / 2;
+
+   u_fifo : entity lib.fifo
+      generic map (
+         WIDTH => WIDTH, -- positive := 8
+         DEPTH => DEPTH  -- positive := 16
+      )
+      port map (
+         clk_i  => clk_i,  -- in std_logic
+         rst_i  => rst_i,  -- in std_logic
+         data_i => data_i, -- in std_logic_vector(WIDTH - 1 downto 0)
+         full_o => full_o  -- out std_logic
+      );
+
+   main : process is
+   begin
+      -- Reset sequence.
+      wait for 10 * CLK_PERIOD;
+      rst_i <= '0';
+      wait until rising_edge(clk_i);
+
+      -- Stimulus.
+
+      std.env.finish;
+      wait;
+   end process;
+
+end architecture;
+`
+	if got := testbench(ent, "lib", nil, ""); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	got := testbench(ent, "lib", nil, "vunit")
+	for _, s := range []string{
+		"context vunit_lib.vunit_context;",
+		"runner_cfg : string",
+		"test_runner_setup(runner, runner_cfg);",
+		"test_runner_cleanup(runner);",
+	} {
+		if !strings.Contains(got, s) {
+			t.Errorf("vunit harness: missing %q", s)
+		}
+	}
+
+	got = testbench(ent, "lib", nil, "osvvm")
+	for _, s := range []string{"context osvvm.OsvvmContext;", "EndOfTestReports;", "std.env.stop;"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("osvvm harness: missing %q", s)
+		}
+	}
+}
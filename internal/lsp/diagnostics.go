@@ -0,0 +1,60 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/vet/vhdl"
+)
+
+func (s *server) publishDiagnostics(uri string) error {
+	path := uriToPath(uri)
+	diags := []diagnostic{}
+
+	if isVHDLFile(path) && len(lspArgs.VetArgs.FilterIgnored([]string{path})) > 0 {
+		for _, v := range vhdl.VetBuffer(path, s.docs[uri]) {
+			line := v.LineNum - 1
+			diags = append(
+				diags,
+				diagnostic{
+					Range: rng{
+						Start: position{Line: line, Character: 0},
+						End:   position{Line: line, Character: uint(len(v.Line))},
+					},
+					Severity: severityWarning,
+					Source:   "thdl",
+					Message:  v.Msg,
+				},
+			)
+		}
+	}
+
+	return s.notify(
+		"textDocument/publishDiagnostics",
+		publishDiagnosticsParams{URI: uri, Diagnostics: diags},
+	)
+}
+
+func isVHDLFile(path string) bool {
+	return strings.HasSuffix(path, ".vhd") || strings.HasSuffix(path, ".vhdl")
+}
+
+// uriToPath converts 'file' scheme URI to the file path.
+// If URI has different scheme, it is returned without modifications.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts file path to the 'file' scheme URI.
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
@@ -0,0 +1,210 @@
+// Package lsp implements Language Server Protocol server communicating over stdio.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/args"
+	"github.com/m-kru/go-thdl/internal/doc"
+	"github.com/m-kru/go-thdl/internal/vet/vhdl"
+)
+
+var lspArgs args.LspArgs
+
+func Lsp(args args.LspArgs) {
+	lspArgs = args
+
+	if err := vhdl.Configure(lspArgs.VetArgs); err != nil {
+		log.Fatalf("lsp: vet: %v", err)
+	}
+
+	s := makeServer(os.Stdin, os.Stdout)
+	s.scanSymbols = func() { doc.Scan(lspArgs.DocArgs) }
+
+	os.Exit(s.serve())
+}
+
+type server struct {
+	reader *bufio.Reader
+	writer io.Writer
+
+	// Content of opened documents, the key is document URI.
+	docs map[string][]byte
+
+	// scanSymbols is called once during the initialization.
+	scanSymbols func()
+
+	shutdown bool
+}
+
+func makeServer(r io.Reader, w io.Writer) server {
+	return server{
+		reader:      bufio.NewReader(r),
+		writer:      w,
+		docs:        map[string][]byte{},
+		scanSymbols: func() {},
+	}
+}
+
+// serve handles messages until the 'exit' notification or EOF.
+// It returns the process exit code.
+func (s *server) serve() int {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return 1
+		} else if err != nil {
+			log.Printf("lsp: %v", err)
+			return 1
+		}
+
+		log.Printf("debug: lsp: received '%s'", msg.Method)
+
+		if msg.Method == "exit" {
+			if s.shutdown {
+				return 0
+			}
+			return 1
+		}
+
+		err = s.handle(msg)
+		if err != nil {
+			log.Printf("lsp: %s: %v", msg.Method, err)
+		}
+	}
+}
+
+func (s *server) handle(msg message) error {
+	var result interface{}
+	var rErr *responseError
+
+	switch msg.Method {
+	case "initialize":
+		s.scanSymbols()
+		res := initializeResult{}
+		res.Capabilities.TextDocumentSync = textDocumentSyncFull
+		res.Capabilities.HoverProvider = true
+		res.Capabilities.DefinitionProvider = true
+		res.ServerInfo.Name = "thdl"
+		res.ServerInfo.Version = args.Version
+		result = res
+	case "initialized":
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/didOpen":
+		params := didOpenParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return err
+		}
+		s.docs[params.TextDocument.URI] = []byte(params.TextDocument.Text)
+		return s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		params := didChangeParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil
+		}
+		// Full synchronization, the last change contains the whole document.
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		s.docs[params.TextDocument.URI] = []byte(text)
+		return s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		params := didCloseParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return s.notify(
+			"textDocument/publishDiagnostics",
+			publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}},
+		)
+	case "textDocument/hover", "textDocument/definition":
+		params := textDocumentPositionParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			rErr = &responseError{Code: invalidParams, Message: err.Error()}
+			break
+		}
+		word := s.wordAt(params.TextDocument.URI, params.Position)
+		if msg.Method == "textDocument/hover" {
+			result = hoverResult(word)
+		} else {
+			result = definitionResult(word)
+		}
+	default:
+		if msg.ID == nil {
+			// Unsupported notifications are silently ignored.
+			return nil
+		}
+		rErr = &responseError{
+			Code: methodNotFound, Message: fmt.Sprintf("method '%s' not supported", msg.Method),
+		}
+	}
+
+	if msg.ID == nil {
+		return nil
+	}
+
+	return s.write(response{JSONRPC: "2.0", ID: msg.ID, Result: result, Error: rErr})
+}
+
+func (s *server) notify(method string, params interface{}) error {
+	return s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// read reads single message with the base protocol header.
+func (s *server) read() (message, error) {
+	msg := message{}
+	length := -1
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return msg, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "Content-Length:") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[len("Content-Length:"):]))
+			if err != nil {
+				return msg, fmt.Errorf("invalid header '%s': %v", line, err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return msg, fmt.Errorf("missing 'Content-Length' header")
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, content); err != nil {
+		return msg, err
+	}
+
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return msg, fmt.Errorf("unmarshalling message: %v", err)
+	}
+
+	return msg, nil
+}
+
+func (s *server) write(v interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+
+	return err
+}
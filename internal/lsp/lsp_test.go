@@ -0,0 +1,86 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":` +
+			`{"uri":"file:///tmp/test.vhd","languageId":"vhdl","version":1,"text":"entity e is\n  rst_n => '0',\n"}}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":` +
+			`{"uri":"file:///tmp/test.vhd","version":2},"contentChanges":[{"text":"  rst_n => '1',\n"}]}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{"textDocument":` +
+			`{"uri":"file:///tmp/test.vhd"},"position":{"line":0,"character":3}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"foo","params":{}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	}
+
+	in := bytes.Buffer{}
+	for _, r := range requests {
+		in.WriteString(fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(r), r))
+	}
+	out := bytes.Buffer{}
+
+	s := makeServer(&in, &out)
+	if code := s.serve(); code != 0 {
+		t.Fatalf("invalid exit code %d, want 0", code)
+	}
+
+	wantMethods := []string{"", "textDocument/publishDiagnostics", "textDocument/publishDiagnostics", "", "", ""}
+	wantDiags := []int{-1, 1, 0, -1, -1, -1}
+
+	outServer := server{reader: bufio.NewReader(&out)}
+	for i, method := range wantMethods {
+		msg, err := outServer.read()
+		if err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		if msg.Method != method {
+			t.Errorf("[%d]: invalid method '%s', want '%s'", i, msg.Method, method)
+		}
+		if wantDiags[i] < 0 {
+			continue
+		}
+		params := publishDiagnosticsParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			t.Fatalf("[%d]: %v", i, err)
+		}
+		if len(params.Diagnostics) != wantDiags[i] {
+			t.Errorf("[%d]: got %d diagnostics, want %d", i, len(params.Diagnostics), wantDiags[i])
+		}
+		if len(params.Diagnostics) > 0 && params.Diagnostics[0].Range.Start.Line != 1 {
+			t.Errorf("[%d]: invalid diagnostic line %d, want 1", i, params.Diagnostics[0].Range.Start.Line)
+		}
+	}
+}
+
+func TestWordAt(t *testing.T) {
+	var tests = []struct {
+		line string
+		char uint
+		word string
+	}{
+		{line: "  signal s : t_foo;", char: 14, word: "t_foo"},
+		{line: "  x <= work.pkg.func(a);", char: 13, word: "work.pkg.func"},
+		{line: "  x <= a;", char: 2, word: "x"},
+		{line: "  x <= a;", char: 5, word: ""},
+		{line: "end pkg.", char: 7, word: "pkg"},
+	}
+
+	for i, test := range tests {
+		s := makeServer(nil, nil)
+		s.docs["uri"] = []byte(test.line)
+		word := s.wordAt("uri", position{Line: 0, Character: test.char})
+		if word != test.word {
+			t.Errorf("[%d]: got '%s', want '%s'", i, word, test.word)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package lsp
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/doc"
+	"github.com/m-kru/go-thdl/internal/doc/lib"
+	"github.com/m-kru/go-thdl/internal/doc/sym"
+	"github.com/m-kru/go-thdl/internal/utils"
+)
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '.' ||
+		('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// wordAt returns the word, possibly containing dots, at given position in the document.
+// Characters are treated as bytes, what is fine for HDL sources.
+func (s *server) wordAt(uri string, pos position) string {
+	lines := bytes.Split(s.docs[uri], []byte("\n"))
+	if int(pos.Line) >= len(lines) {
+		return ""
+	}
+	line := lines[pos.Line]
+	if int(pos.Character) > len(line) {
+		return ""
+	}
+
+	start := int(pos.Character)
+	for start > 0 && isWordByte(line[start-1]) {
+		start -= 1
+	}
+	end := int(pos.Character)
+	for end < len(line) && isWordByte(line[end]) {
+		end += 1
+	}
+
+	word := strings.Trim(string(line[start:end]), ".")
+
+	// The symbol path can have at most 4 elements.
+	if elems := strings.Split(word, "."); len(elems) > 4 {
+		word = strings.Join(elems[len(elems)-4:], ".")
+	}
+
+	return word
+}
+
+func lookup(word string) []sym.Symbol {
+	syms := []sym.Symbol{}
+
+	if word == "" || utils.IsTooGeneralPath(word) {
+		return syms
+	}
+
+	for _, s := range doc.Lookup(word) {
+		syms = append(syms, s...)
+	}
+
+	return syms
+}
+
+func hoverResult(word string) interface{} {
+	syms := lookup(word)
+	if len(syms) == 0 {
+		return nil
+	}
+
+	b := strings.Builder{}
+	for i, s := range syms {
+		if i > 0 {
+			b.WriteString("\n---\n\n")
+		}
+		d, code := s.DocCode()
+		if d != "" {
+			b.WriteString(utils.VHDLDeindentDecomment(d))
+			b.WriteString("\n")
+		}
+		b.WriteString("```vhdl\n")
+		b.WriteString(utils.Deindent(code))
+		b.WriteString("```\n")
+	}
+
+	return hover{Contents: markupContent{Kind: "markdown", Value: b.String()}}
+}
+
+func definitionResult(word string) interface{} {
+	locs := []location{}
+
+	for _, s := range lookup(word) {
+		// Libraries have no single declaration.
+		if _, ok := s.(*lib.Library); ok {
+			continue
+		}
+		if s.Filepath() == "" || s.LineNum() == 0 {
+			continue
+		}
+		line := uint(s.LineNum() - 1)
+		locs = append(
+			locs,
+			location{
+				URI:   pathToURI(s.Filepath()),
+				Range: rng{Start: position{Line: line}, End: position{Line: line}},
+			},
+		)
+	}
+
+	if len(locs) == 0 {
+		return nil
+	}
+
+	return locs
+}
@@ -14,8 +14,27 @@ func ViolationCount() uint32 {
 
 var fmtStr = "%s: %s\n%d:%s\n\n"
 
+// ReportFunc is the signature of functions used for reporting violations.
+type ReportFunc func(filepath string, msg string, lineNum uint, line []byte)
+
+// Violation represents single violation.
+// It is used when violations must be collected instead of being printed.
+type Violation struct {
+	Filepath string
+	Msg      string
+	LineNum  uint
+	Line     string
+}
+
 func Report(filepath string, msg string, lineNum uint, line []byte) {
 	atomic.AddUint32(&violationCounter, 1)
 
 	fmt.Printf(fmtStr, filepath, msg, lineNum, string(line))
 }
+
+// ReportWithDetails reports violation followed by details, for example a diff.
+func ReportWithDetails(filepath string, msg string, lineNum uint, line []byte, details string) {
+	atomic.AddUint32(&violationCounter, 1)
+
+	fmt.Printf("%s: %s\n%d:%s\n%s\n", filepath, msg, lineNum, string(line), details)
+}
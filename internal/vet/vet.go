@@ -27,5 +27,5 @@ func Vet(args args.VetArgs) {
 	}
 
 	wg.Add(1)
-	vhdl.Vet(vhdlFiles, &wg)
+	vhdl.Vet(args, vhdlFiles, &wg)
 }
@@ -0,0 +1,115 @@
+package vhdl
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
+
+var subprogramRegexp *regexp.Regexp = regexp.MustCompile(`^\s*((pure|impure)\s+)?(function|procedure)\b`)
+var isRegexp *regexp.Regexp = regexp.MustCompile(`\bis\b`)
+var isNewRegexp *regexp.Regexp = regexp.MustCompile(`\bis\s+new\b`)
+var endRegexp *regexp.Regexp = regexp.MustCompile(`^\s*end\b`)
+var endNotSubprogramRegexp *regexp.Regexp = regexp.MustCompile(`^\s*end\s+(record|protected|units)\b`)
+var endArchitectureRegexp *regexp.Regexp = regexp.MustCompile(`^\s*end\s*(architecture\b|;)`)
+
+type archEvent int
+
+const (
+	archNone archEvent = iota
+	archStart
+	archBegin
+	archEnd
+	archSubprogramEnd
+)
+
+// archContext tracks the position within the architecture.
+// It is shared by scopes that need to know the architecture structure.
+type archContext struct {
+	name    string
+	lineNum uint
+	line    string
+
+	inDeclarativePart bool
+	inStatementPart   bool
+
+	pendingSubprogram bool
+	subprogramDepth   int
+}
+
+func (ac *archContext) inArch() bool {
+	return ac.inDeclarativePart || ac.inStatementPart
+}
+
+// update must be called with decommented lowercase line.
+// It returns the event caused by the line.
+func (ac *archContext) update(code []byte, lineNum uint, line []byte) archEvent {
+	if !ac.inArch() {
+		if sm := re.ArchitectureDeclaration.FindSubmatch(code); len(sm) > 0 {
+			*ac = archContext{
+				name: string(sm[1]), lineNum: lineNum, line: string(line), inDeclarativePart: true,
+			}
+			return archStart
+		}
+		return archNone
+	}
+
+	if ac.trackSubprograms(code) {
+		return archSubprogramEnd
+	}
+
+	if ac.inDeclarativePart {
+		if ac.subprogramDepth == 0 && len(startsWithBegin.FindIndex(code)) > 0 {
+			ac.inDeclarativePart = false
+			ac.inStatementPart = true
+			return archBegin
+		}
+		return archNone
+	}
+
+	if ac.subprogramDepth == 0 && (len(endArchitectureRegexp.FindIndex(code)) > 0 ||
+		bytes.HasPrefix(bytes.TrimSpace(code), []byte("end "+ac.name))) {
+		ac.inStatementPart = false
+		return archEnd
+	}
+
+	return archNone
+}
+
+// trackSubprograms tracks subprogram bodies, as their 'begin' and 'end' keywords
+// must not be confused with architecture ones. It returns true if line ends subprogram body.
+func (ac *archContext) trackSubprograms(code []byte) bool {
+	if len(subprogramRegexp.FindIndex(code)) > 0 {
+		ac.pendingSubprogram = true
+	}
+
+	if ac.pendingSubprogram {
+		if len(isNewRegexp.FindIndex(code)) > 0 {
+			ac.pendingSubprogram = false
+		} else if len(isRegexp.FindIndex(code)) > 0 {
+			ac.pendingSubprogram = false
+			ac.subprogramDepth += 1
+		} else if bytes.Contains(code, []byte(";")) {
+			ac.pendingSubprogram = false
+		}
+		return false
+	}
+
+	if ac.subprogramDepth > 0 &&
+		len(endRegexp.FindIndex(code)) > 0 && len(endNotSubprogramRegexp.FindIndex(code)) == 0 {
+		// Process declarative part can't contain 'end process', so this is fine.
+		if len(endProcessRegexp.FindIndex(code)) == 0 {
+			ac.subprogramDepth -= 1
+			return true
+		}
+	}
+
+	return false
+}
+
+// decomment returns lowercase line without the comment and with string literals contents removed.
+func decomment(line []byte) []byte {
+	code := bytes.ToLower(bytes.Split(line, []byte("--"))[0])
+	return stringLiteralRegexp.ReplaceAll(code, []byte(`""`))
+}
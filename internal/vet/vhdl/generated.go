@@ -0,0 +1,20 @@
+package vhdl
+
+import (
+	gen "github.com/m-kru/go-thdl/internal/gen/vhdl"
+)
+
+// checkGeneratedCode returns violations for generated code modified by hand.
+// As the check requires the whole file content, violations are grouped by line numbers.
+func checkGeneratedCode(content []byte) map[uint][]violation {
+	violations := map[uint][]violation{}
+
+	for _, e := range gen.FindEdits(content) {
+		violations[e.LineNum] = append(
+			violations[e.LineNum],
+			violation{rule: ruleGeneratedCodeEdited, msg: e.Msg, lineNum: e.LineNum, line: e.Line},
+		)
+	}
+
+	return violations
+}
@@ -0,0 +1,122 @@
+package vhdl
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
+
+var genericMapRegexp *regexp.Regexp = regexp.MustCompile(`\bgeneric\s+map\b`)
+var associationRegexp *regexp.Regexp = regexp.MustCompile(`(\w+)\s*=>\s*("[^"]*"|[^,()]+)`)
+
+var booleanGenericRegexp *regexp.Regexp = regexp.MustCompile(
+	`^((en|enable|use|has|is|do|with|include)_\w+|\w+_(en|enable|enabled))$`,
+)
+var widthGenericRegexp *regexp.Regexp = regexp.MustCompile(`_w(idth)?$`)
+var frequencyGenericRegexp *regexp.Regexp = regexp.MustCompile(`(^|_)freq(uency)?(_|$)`)
+var integerLiteralRegexp *regexp.Regexp = regexp.MustCompile(`^[+-]?\d[\d_]*$`)
+var identifierStartRegexp *regexp.Regexp = regexp.MustCompile(`^[a-z]\w*`)
+
+type genericMapContext struct {
+	inGenericMap bool
+	depth        int
+}
+
+// update updates the context and returns the part of the line within the generic map.
+func (gc *genericMapContext) update(line []byte) []byte {
+	start := 0
+	if idx := genericMapRegexp.FindIndex(line); len(idx) > 0 {
+		gc.inGenericMap = true
+		gc.depth = 0
+		start = idx[1]
+	} else if !gc.inGenericMap {
+		return nil
+	}
+
+	for i := start; i < len(line); i++ {
+		switch line[i] {
+		case '(':
+			gc.depth += 1
+		case ')':
+			gc.depth -= 1
+			if gc.depth == 0 {
+				gc.inGenericMap = false
+				return line[start:i]
+			}
+		}
+	}
+
+	return line[start:]
+}
+
+// stripGenericAffixes removes typical generic and constant name prefixes and suffixes.
+func stripGenericAffixes(name []byte) []byte {
+	for _, p := range [][]byte{[]byte("g_"), []byte("c_")} {
+		name = bytes.TrimPrefix(name, p)
+	}
+	return bytes.TrimSuffix(name, []byte("_g"))
+}
+
+// checkGenericMapping returns name of the violated rule as the first value.
+func checkGenericMapping(line []byte, gc *genericMapContext) (string, string, bool) {
+	line = bytes.Split(line, []byte("--"))[0]
+
+	assocs := gc.update(line)
+	if assocs == nil {
+		return "", "", true
+	}
+
+	for _, sm := range associationRegexp.FindAllSubmatch(assocs, -1) {
+		formal := stripGenericAffixes(sm[1])
+		actual := bytes.TrimSpace(sm[2])
+
+		if len(booleanGenericRegexp.Find(formal)) > 0 && len(integerLiteralRegexp.Find(actual)) > 0 {
+			return ruleGenericBooleanMappedToInteger,
+				fmt.Sprintf("boolean generic '%s' mapped to integer literal", sm[1]),
+				false
+		}
+
+		if len(widthGenericRegexp.Find(formal)) > 0 && bytes.HasPrefix(actual, []byte(`"`)) {
+			return ruleGenericWidthMappedToString,
+				fmt.Sprintf("width generic '%s' mapped to string literal", sm[1]),
+				false
+		}
+
+		if len(frequencyGenericRegexp.Find(formal)) > 0 {
+			if msg, ok := checkFrequencyGenericMapping(formal, actual); !ok {
+				return ruleGenericFrequencyMismatch, msg, false
+			}
+		}
+	}
+
+	return "", "", true
+}
+
+// checkFrequencyGenericMapping checks whether frequency generic of a named clock,
+// for example 'sys_clk_freq_hz', is mapped to the constant of the same clock.
+func checkFrequencyGenericMapping(formal []byte, actual []byte) (string, bool) {
+	actual = identifierStartRegexp.Find(actual)
+	if actual == nil {
+		return "", true
+	}
+	actual = stripGenericAffixes(actual)
+
+	// Clocks with frequencies in names are already handled by the clock scope.
+	if len(re.ClockFrequency.FindIndex(formal)) > 0 && len(re.ClockFrequency.FindIndex(actual)) > 0 {
+		return "", true
+	}
+
+	formalClk := re.ClockName.FindSubmatch(formal)
+	actualClk := re.ClockName.FindSubmatch(actual)
+	if len(formalClk) > 0 && len(actualClk) > 0 && !bytes.Equal(formalClk[1], actualClk[1]) {
+		return fmt.Sprintf(
+				"frequency generic of '%s' clock mapped to '%s' clock constant",
+				formalClk[1], actualClk[1],
+			),
+			false
+	}
+
+	return "", true
+}
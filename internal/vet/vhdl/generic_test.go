@@ -0,0 +1,62 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestCheckGenericMapping(t *testing.T) {
+	var tests = []struct {
+		line string
+		rule string
+		ok   bool
+	}{
+		// Invalid mappings
+		{line: "generic map (g_enable_ecc => 1)", rule: ruleGenericBooleanMappedToInteger, ok: false},
+		{line: "generic map (use_dsp => 0, width => 8)", rule: ruleGenericBooleanMappedToInteger, ok: false},
+		{line: "generic map (fifo_en_g => 1)", rule: ruleGenericBooleanMappedToInteger, ok: false},
+		{line: "generic map (g_data_width => \"8\")", rule: ruleGenericWidthMappedToString, ok: false},
+		{line: "generic map (addr_w => \"16\")", rule: ruleGenericWidthMappedToString, ok: false},
+		{line: "generic map (g_sys_clk_freq_hz => c_eth_clk_freq_hz)", rule: ruleGenericFrequencyMismatch, ok: false},
+		{line: "generic map (ref_clk_freq => ddr_clk_freq / 2)", rule: ruleGenericFrequencyMismatch, ok: false},
+		// Valid mappings
+		{line: "generic map (g_enable_ecc => true)", rule: "", ok: true},
+		{line: "generic map (g_count => 1)", rule: "", ok: true},
+		{line: "generic map (g_data_width => 8)", rule: "", ok: true},
+		{line: "generic map (g_name => \"8\")", rule: "", ok: true},
+		{line: "generic map (g_sys_clk_freq_hz => c_sys_clk_freq_hz)", rule: "", ok: true},
+		{line: "generic map (g_clk_freq_hz => c_eth_clk_freq_hz)", rule: "", ok: true},
+		{line: "generic map (g_sys_clk_freq_hz => 100_000_000)", rule: "", ok: true},
+		{line: "port map (enable_i => 1)", rule: "", ok: true},
+	}
+
+	for i, test := range tests {
+		gc := genericMapContext{}
+		rule, _, ok := checkGenericMapping([]byte(test.line), &gc)
+		if rule != test.rule || ok != test.ok {
+			t.Errorf("[%d]: got (%v, %v); want (%v, %v)", i, rule, ok, test.rule, test.ok)
+		}
+	}
+}
+
+func TestGenericMapContext(t *testing.T) {
+	var lines = []struct {
+		line string
+		ok   bool
+	}{
+		{line: "u_fifo : entity work.fifo", ok: true},
+		{line: "generic map (", ok: true},
+		{line: "   g_depth => f(1),", ok: true},
+		{line: "   g_use_ram => 1", ok: false},
+		{line: ")", ok: true},
+		{line: "port map (", ok: true},
+		{line: "   use_ram_i => 1", ok: true},
+	}
+
+	gc := genericMapContext{}
+	for i, l := range lines {
+		_, _, ok := checkGenericMapping([]byte(l.line), &gc)
+		if ok != l.ok {
+			t.Errorf("[%d]: got %v; want %v", i, ok, l.ok)
+		}
+	}
+}
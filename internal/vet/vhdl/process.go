@@ -30,7 +30,8 @@ func (pc processContext) inSensitivityList(s string) bool {
 	return false
 }
 
-func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext) (string, bool) {
+// checkProcessSensitivityList returns name of the violated rule as the first value.
+func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext) (string, string, bool) {
 	if matches := processWithSensitivityListRegexp.FindSubmatch(line); len(matches) > 0 {
 		pc.sensitivityListLineNum = lineNum
 		pc.sensitivityListLine = string(line)
@@ -39,10 +40,10 @@ func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext)
 		pc.sensitivityListLineNum = 0
 		pc.sensitivityListLine = ""
 		pc.sensitivityList = []string{}
-		return "", true
+		return "", "", true
 	} else if len(processRegexp.FindIndex(line)) > 0 {
 		if aux := startsWithBegin.FindIndex(line); len(aux) > 0 {
-			return "", true
+			return "", "", true
 		}
 		pc.sensitivityListLineNum = lineNum
 		pc.sensitivityListLine = string(line)
@@ -52,17 +53,18 @@ func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext)
 	if matches := ingEdgeRegexp.FindSubmatch(line); len(matches) > 0 {
 		// Ignore typical test bench use cases.
 		if aux := startsWithWait.FindIndex(line); len(aux) > 0 {
-			return "", true
+			return "", "", true
 		}
 		// Ignore some rare, but synthesizable constructs.
 		if bytes.Contains(line, []byte("<=")) && bytes.Contains(line, []byte("when")) {
-			return "", true
+			return "", "", true
 		}
 
 		signal := matches[2]
 
 		if len(pc.sensitivityList) == 0 {
-			return fmt.Sprintf(
+			return ruleProcessMissingSensitivityList,
+				fmt.Sprintf(
 					"'%s' found in the edge function, but sensitivity list is missing\n%d:%s",
 					signal, pc.sensitivityListLineNum, pc.sensitivityListLine,
 				),
@@ -70,7 +72,8 @@ func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext)
 		}
 
 		if !pc.inSensitivityList(string(signal)) {
-			return fmt.Sprintf(
+			return ruleProcessSignalMissingInSensitivityList,
+				fmt.Sprintf(
 					"'%s' not found in the sensitivity list\n%d:%s",
 					signal, pc.sensitivityListLineNum, pc.sensitivityListLine,
 				),
@@ -78,7 +81,7 @@ func checkProcessSensitivityList(line []byte, lineNum uint, pc *processContext)
 		}
 	}
 
-	return "", true
+	return "", "", true
 }
 
 func parseSensitivityList(s []byte) []string {
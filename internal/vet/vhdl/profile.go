@@ -0,0 +1,159 @@
+package vhdl
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/args"
+	gen "github.com/m-kru/go-thdl/internal/gen/vhdl"
+	"github.com/m-kru/go-thdl/internal/utils"
+)
+
+// Rule names. They are used in the .thdl.yml file to enable or disable rules in profiles.
+const (
+	ruleClockFrequencyMismatch                = "clock-frequency-mismatch"
+	ruleGeneratedCodeEdited                   = "generated-code-edited"
+	ruleGenericBooleanMappedToInteger         = "generic-boolean-mapped-to-integer"
+	ruleGenericWidthMappedToString            = "generic-width-mapped-to-string"
+	ruleGenericFrequencyMismatch              = "generic-frequency-mismatch"
+	ruleResetPortMapping                      = "reset-port-mapping"
+	ruleResetIfCondition                      = "reset-if-condition"
+	ruleProcessMissingSensitivityList         = "process-missing-sensitivity-list"
+	ruleProcessSignalMissingInSensitivityList = "process-signal-missing-in-sensitivity-list"
+	ruleProcessMissingWait                    = "process-missing-wait"
+	ruleProcessMissingFinalWait               = "process-missing-final-wait"
+	ruleSignalNeverUsed                       = "signal-never-used"
+	ruleSignalNeverRead                       = "signal-never-read"
+	ruleSignalNeverAssigned                   = "signal-never-assigned"
+	ruleSignalMultipleDrivers                 = "signal-multiple-drivers"
+	ruleTestbenchMissingFinish                = "testbench-missing-finish"
+)
+
+// Rules returns names of all rules sorted in alphabetical order.
+func Rules() []string {
+	rules := []string{}
+	for r := range defaultRTLProfile {
+		rules = append(rules, r)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// profile maps rule name to the enable status.
+type profile map[string]bool
+
+func (p profile) enabled(rule string) bool { return p[rule] }
+
+var defaultRTLProfile profile = profile{
+	ruleClockFrequencyMismatch:                true,
+	ruleGeneratedCodeEdited:                   true,
+	ruleGenericBooleanMappedToInteger:         true,
+	ruleGenericWidthMappedToString:            true,
+	ruleGenericFrequencyMismatch:              true,
+	ruleResetPortMapping:                      true,
+	ruleResetIfCondition:                      true,
+	ruleProcessMissingSensitivityList:         true,
+	ruleProcessSignalMissingInSensitivityList: true,
+	ruleProcessMissingWait:                    true,
+	ruleProcessMissingFinalWait:               false,
+	ruleSignalNeverUsed:                       true,
+	ruleSignalNeverRead:                       true,
+	ruleSignalNeverAssigned:                   true,
+	ruleSignalMultipleDrivers:                 true,
+	ruleTestbenchMissingFinish:                false,
+}
+
+var defaultTBProfile profile = profile{
+	ruleClockFrequencyMismatch:                true,
+	ruleGeneratedCodeEdited:                   true,
+	ruleGenericBooleanMappedToInteger:         true,
+	ruleGenericWidthMappedToString:            true,
+	ruleGenericFrequencyMismatch:              true,
+	ruleResetPortMapping:                      true,
+	ruleResetIfCondition:                      true,
+	ruleProcessMissingSensitivityList:         false,
+	ruleProcessSignalMissingInSensitivityList: true,
+	ruleProcessMissingWait:                    true,
+	ruleProcessMissingFinalWait:               true,
+	ruleSignalNeverUsed:                       true,
+	ruleSignalNeverRead:                       true,
+	ruleSignalNeverAssigned:                   true,
+	ruleSignalMultipleDrivers:                 true,
+	ruleTestbenchMissingFinish:                true,
+}
+
+var rtlProfile profile = defaultRTLProfile
+var tbProfile profile = defaultTBProfile
+
+// Configure sets rule profiles based on the vet arguments.
+// It must be called before vetting any file.
+func Configure(args args.VetArgs) error {
+	var err error
+
+	rtlProfile, err = makeProfile(defaultRTLProfile, args.Profiles.RTL)
+	if err != nil {
+		return fmt.Errorf("rtl profile: %v", err)
+	}
+
+	tbProfile, err = makeProfile(defaultTBProfile, args.Profiles.TB)
+	if err != nil {
+		return fmt.Errorf("tb profile: %v", err)
+	}
+
+	// Generated code is regenerated in memory to find edits, and it might depend on the tree content.
+	gen.ScanTreeLazily(func() []string { return args.FilterIgnored(utils.GetVHDLFilePaths()) })
+
+	return nil
+}
+
+func makeProfile(defaults profile, overrides map[string]bool) (profile, error) {
+	p := profile{}
+	for rule, enabled := range defaults {
+		p[rule] = enabled
+	}
+
+	for rule, enabled := range overrides {
+		if _, ok := p[rule]; !ok {
+			return nil, fmt.Errorf(
+				"invalid rule '%s', valid rules are: %s", rule, strings.Join(Rules(), ", "),
+			)
+		}
+		p[rule] = enabled
+	}
+
+	return p, nil
+}
+
+var entityDeclarationRegexp *regexp.Regexp = regexp.MustCompile(`^\s*entity\s+(\w+)\s+is\b`)
+var architectureOfRegexp *regexp.Regexp = regexp.MustCompile(`^\s*architecture\s+\w+\s+of\s+(\w+)\s+is\b`)
+
+// initialProfile returns the profile used until the first design unit, based on the file name.
+func initialProfile(path string) profile {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if utils.IsTestbench(strings.ToLower(name)) {
+		return tbProfile
+	}
+	return rtlProfile
+}
+
+// unitProfile returns the profile for the design unit declared in the line.
+// If the line doesn't start entity or architecture, then current profile is returned.
+func unitProfile(lineLower []byte, current profile) profile {
+	var name []byte
+
+	if sm := entityDeclarationRegexp.FindSubmatch(lineLower); len(sm) > 0 {
+		name = sm[1]
+	} else if sm := architectureOfRegexp.FindSubmatch(lineLower); len(sm) > 0 {
+		name = sm[1]
+	} else {
+		return current
+	}
+
+	if utils.IsTestbench(string(name)) {
+		return tbProfile
+	}
+	return rtlProfile
+}
@@ -0,0 +1,49 @@
+package vhdl
+
+import (
+	"testing"
+)
+
+func TestUnitProfile(t *testing.T) {
+	var tests = []struct {
+		line string
+		tb   bool
+	}{
+		{line: "entity tb is", tb: true},
+		{line: "entity tb_fifo is", tb: true},
+		{line: "  entity fifo_tb is", tb: true},
+		{line: "architecture test of tb_fifo is", tb: true},
+		{line: "entity fifo is", tb: false},
+		{line: "architecture rtl of fifo is", tb: false},
+		{line: "entity tbl is", tb: false},
+	}
+
+	for i, test := range tests {
+		p := unitProfile([]byte(test.line), nil)
+		if p.enabled(ruleTestbenchMissingFinish) != test.tb {
+			t.Errorf("[%d]: got testbench %v; want %v", i, !test.tb, test.tb)
+		}
+	}
+
+	if p := unitProfile([]byte("package p is"), tbProfile); p.enabled(ruleTestbenchMissingFinish) != true {
+		t.Errorf("profile changed on package declaration")
+	}
+}
+
+func TestMakeProfile(t *testing.T) {
+	p, err := makeProfile(defaultTBProfile, map[string]bool{ruleProcessMissingSensitivityList: true})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !p.enabled(ruleProcessMissingSensitivityList) {
+		t.Errorf("rule not enabled")
+	}
+	if defaultTBProfile.enabled(ruleProcessMissingSensitivityList) {
+		t.Errorf("default profile modified")
+	}
+
+	_, err = makeProfile(defaultTBProfile, map[string]bool{"foo": true})
+	if err == nil {
+		t.Errorf("invalid rule accepted")
+	}
+}
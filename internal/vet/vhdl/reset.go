@@ -4,26 +4,23 @@ import (
 	"bytes"
 	"regexp"
 	_ "strings"
-)
 
-var positiveReset string = `re?se?t((p)|(p_i)|(_p)|(_i)|(_p_i)|(_i_p))?\b`
-var negativeReset string = `re?se?t((n)|(n_i)|(_n)|(_n_i)|(_i_n))\b`
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
 
 var startsWithWhenRegexp *regexp.Regexp = regexp.MustCompile(`^\s*when\b`)
 
-var positiveResetPortMapRegexp *regexp.Regexp = regexp.MustCompile(positiveReset + `\s*=>\s*(.+)`)
-var positiveResetRegexp *regexp.Regexp = regexp.MustCompile(positiveReset)
+var positiveResetPortMapRegexp *regexp.Regexp = regexp.MustCompile(re.PositiveResetPattern + `\s*=>\s*(.+)`)
 
-var negativeResetPortMapRegexp *regexp.Regexp = regexp.MustCompile(negativeReset + `\s*=>\s*(.+)`)
-var negativeResetRegexp *regexp.Regexp = regexp.MustCompile(negativeReset)
+var negativeResetPortMapRegexp *regexp.Regexp = regexp.MustCompile(re.NegativeResetPattern + `\s*=>\s*(.+)`)
 
 var startsWithNotRegexp *regexp.Regexp = regexp.MustCompile(`^not((\s+)|(\s*\())`)
 
-var positiveResetInvalidIfConditionRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + positiveReset + `\s*=\s*'0'((\s*)|(\s*\)\s*))then`)
-var positiveResetInvalidIfConditionNoRHSRegexp = regexp.MustCompile(`^\s*if\s+not(\s+|(\s*\(\s*))` + positiveReset + `(\s*|(\s*\)\s*))then`)
+var positiveResetInvalidIfConditionRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + re.PositiveResetPattern + `\s*=\s*'0'((\s*)|(\s*\)\s*))then`)
+var positiveResetInvalidIfConditionNoRHSRegexp = regexp.MustCompile(`^\s*if\s+not(\s+|(\s*\(\s*))` + re.PositiveResetPattern + `(\s*|(\s*\)\s*))then`)
 
-var negativeResetInvalidIfConditionRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + negativeReset + `\s*=\s*'1'((\s*)|(\s*\)\s*))then`)
-var negativeResetInvalidIfConditionNoRHSRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + negativeReset + `((\s+)|(\s*\)\s*))then`)
+var negativeResetInvalidIfConditionRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + re.NegativeResetPattern + `\s*=\s*'1'((\s*)|(\s*\)\s*))then`)
+var negativeResetInvalidIfConditionNoRHSRegexp = regexp.MustCompile(`^\s*if((\s+)|(\s*\(\s*))` + re.NegativeResetPattern + `((\s+)|(\s*\)\s*))then`)
 
 func checkResetPortMapping(line []byte) (string, bool) {
 	if len(startsWithWhenRegexp.FindIndex(line)) > 0 {
@@ -57,9 +54,9 @@ func checkPositiveResetPortMapping(matches [][]byte) (string, bool) {
 
 	reset := ""
 
-	if len(negativeResetRegexp.FindIndex(assignee)) > 0 {
+	if len(re.NegativeReset.FindIndex(assignee)) > 0 {
 		reset = "negative"
-	} else if len(positiveResetRegexp.FindIndex(assignee)) > 0 {
+	} else if len(re.PositiveReset.FindIndex(assignee)) > 0 {
 		reset = "positive"
 	}
 
@@ -86,9 +83,9 @@ func checkNegativeResetPortMapping(matches [][]byte) (string, bool) {
 
 	reset := ""
 
-	if len(negativeResetRegexp.FindIndex(assignee)) > 0 {
+	if len(re.NegativeReset.FindIndex(assignee)) > 0 {
 		reset = "negative"
-	} else if len(positiveResetRegexp.FindIndex(assignee)) > 0 {
+	} else if len(re.PositiveReset.FindIndex(assignee)) > 0 {
 		reset = "positive"
 	}
 
@@ -0,0 +1,245 @@
+package vhdl
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m-kru/go-thdl/internal/vhdl/re"
+)
+
+var signalDeclarationRegexp *regexp.Regexp = regexp.MustCompile(`(?i)^\s*signal\s+([^:]+):`)
+var signalAssignmentRegexp *regexp.Regexp = regexp.MustCompile(
+	`(^|;|=>|\bthen\b|\belse\b|\bselect\??)\s*(\w+\s*:\s*)?(\w+)\s*((\(|\.)[^;]*?)?<=`,
+)
+var portMapRegexp *regexp.Regexp = regexp.MustCompile(`\bport\s+map\b`)
+var processLabelRegexp *regexp.Regexp = regexp.MustCompile(`^\s*(\w+)\s*:\s*(postponed\s+)?process\b`)
+var identifierRegexp *regexp.Regexp = regexp.MustCompile(`\b[a-z]\w*\b`)
+var stringLiteralRegexp *regexp.Regexp = regexp.MustCompile(`"[^"]*"`)
+
+// Words that can precede '<=' at the line start, but are not assignment targets.
+var notAssignmentTargets map[string]bool = map[string]bool{
+	"assert": true, "elsif": true, "if": true, "report": true, "return": true, "until": true, "when": true, "while": true,
+}
+
+type signal struct {
+	name    string
+	lineNum uint
+	line    string
+	ignored bool
+
+	hasDefault bool
+	read       bool
+	assigned   bool
+
+	// Driver of the whole signal, either process or concurrent statement.
+	driver        string
+	driverLineNum uint
+	driverLine    string
+}
+
+type signalContext struct {
+	arch archContext
+
+	// Signals in declaration order and signals by lowercase name.
+	signals     []*signal
+	signalsMap  map[string]*signal
+	pendingDecl []*signal // Signals of a multi-line declaration, waiting for ';'.
+
+	inPortMap bool
+	process   string
+}
+
+// checkSignals must be called for all non-comment lines, including ignored ones.
+// Declarations from ignored lines are not checked.
+// Violations regarding unused signals are returned at the end of the architecture.
+func checkSignals(line []byte, lineNum uint, ignored bool, sc *signalContext) []violation {
+	code := decomment(line)
+
+	switch sc.arch.update(code, lineNum, line) {
+	case archStart:
+		sc.signals = nil
+		sc.signalsMap = map[string]*signal{}
+		sc.pendingDecl = nil
+		sc.inPortMap = false
+		sc.process = ""
+		return nil
+	case archBegin, archSubprogramEnd:
+		return nil
+	case archEnd:
+		return sc.unusedSignals()
+	}
+
+	if sc.arch.inDeclarativePart {
+		sc.scanDeclaration(line, code, lineNum, ignored)
+		return nil
+	} else if sc.arch.inStatementPart {
+		return sc.scanStatement(line, code, lineNum, ignored)
+	}
+
+	return nil
+}
+
+func (sc *signalContext) scanDeclaration(line []byte, code []byte, lineNum uint, ignored bool) {
+	if len(sc.pendingDecl) > 0 {
+		if bytes.Contains(code, []byte(":=")) {
+			for _, s := range sc.pendingDecl {
+				s.hasDefault = true
+			}
+		}
+		if bytes.Contains(code, []byte(";")) {
+			sc.pendingDecl = nil
+		}
+		return
+	}
+
+	sm := signalDeclarationRegexp.FindSubmatchIndex(line)
+	if len(sm) == 0 {
+		return
+	}
+
+	rest := code[sm[1]:]
+	hasDefault := bytes.Contains(rest, []byte(":="))
+
+	decl := []*signal{}
+	for _, name := range strings.Split(string(line[sm[2]:sm[3]]), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		s := &signal{
+			name: name, lineNum: lineNum, line: string(line), ignored: ignored, hasDefault: hasDefault,
+		}
+		decl = append(decl, s)
+		sc.signals = append(sc.signals, s)
+		sc.signalsMap[strings.ToLower(name)] = s
+	}
+
+	if !bytes.Contains(rest, []byte(";")) {
+		sc.pendingDecl = decl
+	}
+}
+
+func (sc *signalContext) scanStatement(line []byte, code []byte, lineNum uint, ignored bool) []violation {
+	var violations []violation
+
+	// Signals can also be declared in blocks and generate statements.
+	if len(signalDeclarationRegexp.FindIndex(code)) > 0 || len(sc.pendingDecl) > 0 {
+		sc.scanDeclaration(line, code, lineNum, ignored)
+		return nil
+	}
+
+	if len(endProcessRegexp.FindIndex(code)) > 0 {
+		sc.process = ""
+	} else if len(processRegexp.FindIndex(code)) > 0 && len(startsWithBegin.FindIndex(code)) == 0 {
+		if sm := processLabelRegexp.FindSubmatch(code); len(sm) > 0 {
+			sc.process = fmt.Sprintf("process '%s'", sm[1])
+		} else {
+			sc.process = fmt.Sprintf("process in line %d", lineNum)
+		}
+	}
+
+	if len(portMapRegexp.FindIndex(code)) > 0 {
+		sc.inPortMap = true
+	}
+
+	// Remove assignment targets, so that they are not treated as read.
+	readPart := []byte{}
+	targets := []*signal{}
+	wholeTargets := []bool{}
+	prevEnd := 0
+	for _, sm := range signalAssignmentRegexp.FindAllSubmatchIndex(code, -1) {
+		name := string(code[sm[6]:sm[7]])
+		if notAssignmentTargets[name] {
+			continue
+		}
+		if s, ok := sc.signalsMap[name]; ok {
+			targets = append(targets, s)
+			wholeTargets = append(wholeTargets, sm[8] < 0)
+		}
+		readPart = append(readPart, code[prevEnd:sm[6]]...)
+		prevEnd = sm[7]
+	}
+	readPart = append(readPart, code[prevEnd:]...)
+
+	for _, idx := range identifierRegexp.FindAllIndex(readPart, -1) {
+		// Skip record fields and attributes.
+		if idx[0] > 0 && (readPart[idx[0]-1] == '.' || readPart[idx[0]-1] == '\'') {
+			continue
+		}
+		s, ok := sc.signalsMap[string(readPart[idx[0]:idx[1]])]
+		if !ok {
+			continue
+		}
+		s.read = true
+		// Port directions are unknown, signal mapped to port might be driven by the instance.
+		if sc.inPortMap {
+			s.assigned = true
+		}
+	}
+
+	for i, target := range targets {
+		target.assigned = true
+		if !wholeTargets[i] {
+			continue
+		}
+		driver := sc.process
+		if driver == "" {
+			driver = fmt.Sprintf("concurrent assignment in line %d", lineNum)
+		}
+		if target.driver == "" {
+			target.driver = driver
+			target.driverLineNum = lineNum
+			target.driverLine = string(line)
+		} else if target.driver != driver && !ignored && !target.ignored {
+			violations = append(
+				violations,
+				violation{
+					rule: ruleSignalMultipleDrivers,
+					msg: fmt.Sprintf(
+						"signal '%s' has multiple drivers, %s and %s\n%d:%s",
+						target.name, target.driver, driver, target.driverLineNum, target.driverLine,
+					),
+					lineNum: lineNum,
+					line:    string(line),
+				},
+			)
+		}
+	}
+
+	if sc.inPortMap && len(re.EndsWithRoundBracketAndSemicolon.FindIndex(code)) > 0 {
+		sc.inPortMap = false
+	}
+
+	return violations
+}
+
+func (sc *signalContext) unusedSignals() []violation {
+	violations := []violation{}
+
+	for _, s := range sc.signals {
+		if s.ignored {
+			continue
+		}
+
+		rule := ""
+		msg := ""
+		if !s.read && !s.assigned {
+			rule = ruleSignalNeverUsed
+			msg = fmt.Sprintf("signal '%s' never used", s.name)
+		} else if !s.read {
+			rule = ruleSignalNeverRead
+			msg = fmt.Sprintf("signal '%s' never read", s.name)
+		} else if !s.assigned && !s.hasDefault {
+			rule = ruleSignalNeverAssigned
+			msg = fmt.Sprintf("signal '%s' never assigned and has no default value", s.name)
+		}
+
+		if msg != "" {
+			violations = append(violations, violation{rule: rule, msg: msg, lineNum: s.lineNum, line: s.line})
+		}
+	}
+
+	return violations
+}
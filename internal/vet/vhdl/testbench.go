@@ -0,0 +1,150 @@
+package vhdl
+
+import (
+	"bytes"
+	"regexp"
+)
+
+var waitRegexp *regexp.Regexp = regexp.MustCompile(`\bwait\b`)
+var finalWaitRegexp *regexp.Regexp = regexp.MustCompile(`\bwait\s*;`)
+var finishRegexp *regexp.Regexp = regexp.MustCompile(
+	`\b(std\.env\.)?(finish|stop)\s*(\(|;)|\btest_runner_cleanup\b|\bendoftestreports\b`,
+)
+var procedureCallRegexp *regexp.Regexp = regexp.MustCompile(`^\s*([\w.]+)\s*(\(|;)`)
+
+// Words that can start a statement looking like a procedure call.
+var notProcedureNames map[string]bool = map[string]bool{
+	"assert": true, "begin": true, "case": true, "else": true, "elsif": true, "end": true,
+	"exit": true, "for": true, "if": true, "loop": true, "next": true, "null": true,
+	"report": true, "return": true, "wait": true, "when": true, "while": true,
+}
+
+type tbProcess struct {
+	lineNum uint
+	line    string
+	ignored bool
+
+	hasSensitivityList bool
+	hasWait            bool
+	hasFinalWait       bool
+	hasProcedureCalls  bool
+	targets            map[string]bool
+}
+
+type testbenchContext struct {
+	arch        archContext
+	archIgnored bool
+	finishFound bool
+	process     *tbProcess
+}
+
+// checkTestbench checks mistakes typical for testbenches. However, it is called for all
+// architectures, and the profile decides whether violations are reported.
+// checkTestbench must be called for all non-comment lines, including ignored ones.
+// Violations of processes and architectures starting in ignored lines are not reported.
+func checkTestbench(line []byte, lineNum uint, ignored bool, tc *testbenchContext) []violation {
+	code := decomment(line)
+
+	switch tc.arch.update(code, lineNum, line) {
+	case archStart:
+		tc.archIgnored = ignored
+		tc.finishFound = false
+		tc.process = nil
+		return nil
+	case archEnd:
+		if tc.finishFound || tc.archIgnored {
+			return nil
+		}
+		return []violation{
+			violation{
+				rule:    ruleTestbenchMissingFinish,
+				msg:     "testbench architecture without std.env.finish or std.env.stop call",
+				lineNum: tc.arch.lineNum,
+				line:    tc.arch.line,
+			},
+		}
+	}
+
+	if !tc.arch.inStatementPart {
+		return nil
+	}
+
+	if len(finishRegexp.FindIndex(code)) > 0 {
+		tc.finishFound = true
+		if tc.process != nil {
+			tc.process.hasFinalWait = true
+		}
+	}
+
+	if len(endProcessRegexp.FindIndex(code)) > 0 {
+		if tc.process == nil {
+			return nil
+		}
+		v := tc.process.check()
+		tc.process = nil
+		return v
+	} else if len(processRegexp.FindIndex(code)) > 0 && len(startsWithBegin.FindIndex(code)) == 0 {
+		tc.process = &tbProcess{
+			lineNum:            lineNum,
+			line:               string(line),
+			ignored:            ignored,
+			hasSensitivityList: len(processWithSensitivityListRegexp.FindIndex(code)) > 0,
+			targets:            map[string]bool{},
+		}
+		return nil
+	}
+
+	p := tc.process
+	if p == nil {
+		return nil
+	}
+
+	if len(waitRegexp.FindIndex(code)) > 0 {
+		p.hasWait = true
+	}
+	if len(finalWaitRegexp.FindIndex(code)) > 0 {
+		p.hasFinalWait = true
+	}
+	if sm := procedureCallRegexp.FindSubmatch(code); len(sm) > 0 && !notProcedureNames[string(sm[1])] &&
+		!bytes.Contains(code, []byte("<=")) && !bytes.Contains(code, []byte(":=")) {
+		p.hasProcedureCalls = true
+	}
+	for _, sm := range signalAssignmentRegexp.FindAllSubmatch(code, -1) {
+		if name := string(sm[3]); !notAssignmentTargets[name] {
+			p.targets[name] = true
+		}
+	}
+
+	return nil
+}
+
+func (p *tbProcess) check() []violation {
+	if p.hasSensitivityList || p.ignored {
+		return nil
+	}
+
+	if !p.hasWait && !p.hasProcedureCalls {
+		return []violation{
+			violation{
+				rule:    ruleProcessMissingWait,
+				msg:     "process without sensitivity list and wait statement",
+				lineNum: p.lineNum,
+				line:    p.line,
+			},
+		}
+	}
+
+	// Processes assigning single signal are most likely clock or reset generators.
+	if !p.hasFinalWait && len(p.targets) > 1 {
+		return []violation{
+			violation{
+				rule:    ruleProcessMissingFinalWait,
+				msg:     "stimulus process without final 'wait;', it restarts after the last statement",
+				lineNum: p.lineNum,
+				line:    p.line,
+			},
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"sync"
 
+	"github.com/m-kru/go-thdl/internal/args"
 	"github.com/m-kru/go-thdl/internal/utils"
 	"github.com/m-kru/go-thdl/internal/vet/rprt"
 )
@@ -16,7 +17,11 @@ var ignoreNextLineRegExp *regexp.Regexp = regexp.MustCompile(`^\s*--thdl:ignore`
 var ignoreThisLineRegExp *regexp.Regexp = regexp.MustCompile(`--thdl:ignore\s*$`)
 var commentLineRegExp *regexp.Regexp = regexp.MustCompile(`^\s*--`)
 
-func Vet(filepaths []string, wg *sync.WaitGroup) {
+func Vet(args args.VetArgs, filepaths []string, wg *sync.WaitGroup) {
+	if err := Configure(args); err != nil {
+		log.Fatalf("vet: %v", err)
+	}
+
 	var filesWg sync.WaitGroup
 
 	for _, fp := range filepaths {
@@ -35,48 +40,116 @@ func vetFile(filepath string, wg *sync.WaitGroup) {
 		return
 	}
 
-	pCtx := processContext{sensitivityList: []string{}}
-
 	f, err := os.ReadFile(filepath)
 	if err != nil {
 		log.Fatalf("error reading file %s: %v", filepath, err)
 	}
 
-	ioScanner := bufio.NewScanner(bytes.NewReader(f))
+	vetBuffer(filepath, f, rprt.Report)
+}
+
+// VetBuffer vets in-memory file content and returns found violations.
+// Violations are not reported to the user and do not increment the violation counter.
+func VetBuffer(filepath string, content []byte) []rprt.Violation {
+	violations := []rprt.Violation{}
+
+	if utils.IsIgnoredVHDLFile(filepath) {
+		return violations
+	}
+
+	report := func(filepath string, msg string, lineNum uint, line []byte) {
+		violations = append(
+			violations,
+			rprt.Violation{Filepath: filepath, Msg: msg, LineNum: lineNum, Line: string(line)},
+		)
+	}
+
+	vetBuffer(filepath, content, report)
+
+	return violations
+}
+
+// violation is used by scopes reporting violations not related with the currently processed line.
+type violation struct {
+	rule    string
+	msg     string
+	lineNum uint
+	line    string
+}
+
+func vetBuffer(filepath string, content []byte, report rprt.ReportFunc) {
+	prof := initialProfile(filepath)
+
+	pCtx := processContext{sensitivityList: []string{}}
+	sigCtx := signalContext{}
+	tbCtx := testbenchContext{}
+	gmCtx := genericMapContext{}
+
+	genViolations := checkGeneratedCode(content)
+
+	reportViolations := func(violations []violation) {
+		for _, v := range violations {
+			if prof.enabled(v.rule) {
+				report(filepath, v.msg, v.lineNum, []byte(v.line))
+			}
+		}
+	}
+
+	ioScanner := bufio.NewScanner(bytes.NewReader(content))
 	lineNum := uint(0)
 	ignoreNextLine := false
 	for ioScanner.Scan() {
 		lineNum += 1
 		line := ioScanner.Bytes()
 
+		// Generated code violations can't be ignored, as the ignore comment also modifies the code.
+		reportViolations(genViolations[lineNum])
+
+		ignore := false
 		if len(ignoreNextLineRegExp.FindIndex(line)) > 0 {
 			ignoreNextLine = true
 			continue
 		} else if ignoreNextLine {
 			ignoreNextLine = false
-			continue
+			ignore = true
 		} else if len(commentLineRegExp.FindIndex(line)) > 0 {
 			continue
 		} else if len(ignoreThisLineRegExp.FindIndex(line)) > 0 {
-			continue
+			ignore = true
 		}
 
 		lineLower := bytes.ToLower(line)
 
-		if msg, ok := checkClockPortMapping(lineLower); !ok {
-			rprt.Report(filepath, msg, lineNum, line)
+		prof = unitProfile(lineLower, prof)
+
+		// Signal scope must see ignored lines, as they still might use signals.
+		reportViolations(checkSignals(line, lineNum, ignore, &sigCtx))
+
+		// Testbench context must see ignored lines, as they still might start or end processes.
+		reportViolations(checkTestbench(line, lineNum, ignore, &tbCtx))
+
+		if ignore {
+			continue
+		}
+
+		if msg, ok := checkClockPortMapping(lineLower); !ok && prof.enabled(ruleClockFrequencyMismatch) {
+			report(filepath, msg, lineNum, line)
+		}
+
+		if rule, msg, ok := checkGenericMapping(lineLower, &gmCtx); !ok && prof.enabled(rule) {
+			report(filepath, msg, lineNum, line)
 		}
 
-		if msg, ok := checkResetPortMapping(lineLower); !ok {
-			rprt.Report(filepath, msg, lineNum, line)
+		if msg, ok := checkResetPortMapping(lineLower); !ok && prof.enabled(ruleResetPortMapping) {
+			report(filepath, msg, lineNum, line)
 		}
 
-		if msg, ok := checkResetIfCondition(lineLower); !ok {
-			rprt.Report(filepath, msg, lineNum, line)
+		if msg, ok := checkResetIfCondition(lineLower); !ok && prof.enabled(ruleResetIfCondition) {
+			report(filepath, msg, lineNum, line)
 		}
 
-		if msg, ok := checkProcessSensitivityList(lineLower, lineNum, &pCtx); !ok {
-			rprt.Report(filepath, msg, lineNum, line)
+		if rule, msg, ok := checkProcessSensitivityList(lineLower, lineNum, &pCtx); !ok && prof.enabled(rule) {
+			report(filepath, msg, lineNum, line)
 		}
 	}
 }
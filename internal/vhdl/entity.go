@@ -0,0 +1,234 @@
+package vhdl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// InterfaceElement is a single generic or port of the entity.
+type InterfaceElement struct {
+	Name string
+	// Mode of the port, empty for generics.
+	Mode string
+	Type string
+	// Default value, empty if not present.
+	Default string
+	// Whole declaration of generic type, subprogram or package, empty for objects.
+	Decl string
+}
+
+type Entity struct {
+	Name     string
+	Generics []InterfaceElement
+	Ports    []InterfaceElement
+}
+
+// Declaration returns the interface element declaration without the name.
+// For generic types, subprograms and packages the whole declaration is returned.
+func (ie InterfaceElement) Declaration() string {
+	if ie.Decl != "" {
+		return ie.Decl
+	}
+	s := ie.Type
+	if ie.Mode != "" {
+		s = ie.Mode + " " + s
+	}
+	if ie.Default != "" {
+		s += " := " + ie.Default
+	}
+	return s
+}
+
+var genericClause *regexp.Regexp = regexp.MustCompile(`(?i)\bgeneric\s*\(`)
+var portClause *regexp.Regexp = regexp.MustCompile(`(?i)\bport\s*\(`)
+var entityName *regexp.Regexp = regexp.MustCompile(`(?i)^\s*entity\s+(\w+)`)
+var whitespace *regexp.Regexp = regexp.MustCompile(`\s+`)
+
+// literalEnd returns the index of the last character of the string or character literal
+// starting at index i. If no literal starts at index i, i is returned.
+func literalEnd(s string, i int) int {
+	switch s[i] {
+	case '"':
+		if j := strings.IndexByte(s[i+1:], '"'); j >= 0 {
+			return i + 1 + j
+		}
+		return len(s) - 1
+	case '\'':
+		// Tick following a name is an attribute or qualified expression, for example t'('a').
+		if i > 0 && (isIdentRune(rune(s[i-1])) || s[i-1] == ')') {
+			return i
+		}
+		if i+2 < len(s) && s[i+2] == '\'' {
+			return i + 2
+		}
+	}
+	return i
+}
+
+// StripComments removes comments from the VHDL code.
+// Double dashes within string and character literals do not start comments.
+func StripComments(code string) string {
+	b := strings.Builder{}
+
+	for _, line := range strings.Split(code, "\n") {
+		for i := 0; i < len(line); i++ {
+			if line[i] == '-' && i+1 < len(line) && line[i+1] == '-' {
+				line = line[:i]
+				break
+			}
+			i = literalEnd(line, i)
+		}
+		b.WriteString(line)
+		b.WriteRune('\n')
+	}
+
+	return b.String()
+}
+
+// parenContent returns the content of the parentheses opening at index start.
+// Parentheses within string and character literals are ignored.
+func parenContent(code string, start int) (string, error) {
+	depth := 0
+	for i := start; i < len(code); i = literalEnd(code, i) + 1 {
+		switch code[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return code[start+1 : i], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unbalanced parentheses")
+}
+
+// splitTopLevel splits the string on the separator not enclosed in parentheses
+// nor in string and character literals.
+func splitTopLevel(s string, sep byte) []string {
+	parts := []string{}
+	depth := 0
+	last := 0
+	for i := 0; i < len(s); i = literalEnd(s, i) + 1 {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// Normalize replaces whitespace sequences with single spaces and trims the string.
+func Normalize(s string) string {
+	return strings.Trim(whitespace.ReplaceAllString(s, " "), " ")
+}
+
+// ParseInterfaceList parses generic or port interface list.
+func ParseInterfaceList(list string, isPort bool) ([]InterfaceElement, error) {
+	elems := []InterfaceElement{}
+
+	for _, decl := range splitTopLevel(list, ';') {
+		decl = Normalize(decl)
+		if decl == "" {
+			continue
+		}
+
+		words := strings.Split(decl, " ")
+		switch strings.ToLower(words[0]) {
+		case "type", "function", "procedure", "impure", "pure", "package":
+			// Generic types, subprograms and packages have no subtype indication.
+			name := words[1]
+			if strings.ToLower(words[0]) == "impure" || strings.ToLower(words[0]) == "pure" {
+				name = words[2]
+			}
+			name = strings.Split(name, "(")[0]
+			elems = append(elems, InterfaceElement{Name: name, Decl: decl})
+			continue
+		case "constant", "signal", "variable":
+			decl = strings.Trim(decl[len(words[0]):], " ")
+		}
+
+		colon := strings.Index(decl, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("missing ':' in interface declaration '%s'", decl)
+		}
+
+		names := strings.Split(decl[:colon], ",")
+		rest := strings.Trim(decl[colon+1:], " ")
+
+		dflt := ""
+		if i := strings.Index(rest, ":="); i >= 0 {
+			dflt = strings.Trim(rest[i+2:], " ")
+			rest = strings.Trim(rest[:i], " ")
+		}
+
+		mode := ""
+		if isPort {
+			mode = "in"
+			words := strings.SplitN(rest, " ", 2)
+			switch strings.ToLower(words[0]) {
+			case "in", "out", "inout", "buffer", "linkage":
+				mode = strings.ToLower(words[0])
+				if len(words) == 2 {
+					rest = words[1]
+				} else {
+					rest = ""
+				}
+			}
+		}
+
+		for _, n := range names {
+			elems = append(elems, InterfaceElement{Name: strings.Trim(n, " "), Mode: mode, Type: rest, Default: dflt})
+		}
+	}
+
+	return elems, nil
+}
+
+// ParseEntity parses the entity declaration code.
+func ParseEntity(code string) (Entity, error) {
+	code = StripComments(code)
+
+	ent := Entity{}
+
+	sm := entityName.FindStringSubmatch(code)
+	if sm == nil {
+		return ent, fmt.Errorf("missing entity name")
+	}
+	ent.Name = sm[1]
+
+	portStart := len(code)
+	if loc := portClause.FindStringIndex(code); loc != nil {
+		portStart = loc[0]
+		list, err := parenContent(code, loc[1]-1)
+		if err != nil {
+			return ent, fmt.Errorf("port clause: %v", err)
+		}
+		ent.Ports, err = ParseInterfaceList(list, true)
+		if err != nil {
+			return ent, fmt.Errorf("port clause: %v", err)
+		}
+	}
+
+	// Generic clause always precedes the port clause.
+	if loc := genericClause.FindStringIndex(code[:portStart]); loc != nil {
+		list, err := parenContent(code, loc[1]-1)
+		if err != nil {
+			return ent, fmt.Errorf("generic clause: %v", err)
+		}
+		ent.Generics, err = ParseInterfaceList(list, false)
+		if err != nil {
+			return ent, fmt.Errorf("generic clause: %v", err)
+		}
+	}
+
+	return ent, nil
+}
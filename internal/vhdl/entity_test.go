@@ -0,0 +1,142 @@
+package vhdl
+
+import (
+	"reflect"
+	"testing"
+)
+
+const fifoCode = `entity fifo is
+   generic (
+      WIDTH : positive := 8; -- Data width
+      DEPTH : positive := 16
+   );
+   port (
+      clk_i, rst_i : in std_logic;
+      data_i : in  std_logic_vector(WIDTH - 1 downto 0);
+      full_o : out std_logic
+   );
+end entity;`
+
+func TestParseEntity(t *testing.T) {
+	ent, err := ParseEntity(fifoCode)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	want := Entity{
+		Name: "fifo",
+		Generics: []InterfaceElement{
+			{Name: "WIDTH", Type: "positive", Default: "8"},
+			{Name: "DEPTH", Type: "positive", Default: "16"},
+		},
+		Ports: []InterfaceElement{
+			{Name: "clk_i", Mode: "in", Type: "std_logic"},
+			{Name: "rst_i", Mode: "in", Type: "std_logic"},
+			{Name: "data_i", Mode: "in", Type: "std_logic_vector(WIDTH - 1 downto 0)"},
+			{Name: "full_o", Mode: "out", Type: "std_logic"},
+		},
+	}
+	if !reflect.DeepEqual(ent, want) {
+		t.Errorf("\ngot:  %+v\nwant: %+v", ent, want)
+	}
+}
+
+func TestParseInterfaceList(t *testing.T) {
+	var tests = []struct {
+		list   string
+		isPort bool
+		want   []InterfaceElement
+	}{
+		{
+			"signal a : std_logic := '1'; b : inout std_logic_vector(f(1, 2) downto 0)", true,
+			[]InterfaceElement{
+				{Name: "a", Mode: "in", Type: "std_logic", Default: "'1'"},
+				{Name: "b", Mode: "inout", Type: "std_logic_vector(f(1, 2) downto 0)"},
+			},
+		},
+		{
+			"type t_data; impure function rand return t_data; constant C : string := \"x\"", false,
+			[]InterfaceElement{
+				{Name: "t_data", Decl: "type t_data"},
+				{Name: "rand", Decl: "impure function rand return t_data"},
+				{Name: "C", Type: "string", Default: "\"x\""},
+			},
+		},
+		{
+			"G_SEP : character := ';'; G_DELIMS : string := \"(;)\"; G_OPEN : character := '('", false,
+			[]InterfaceElement{
+				{Name: "G_SEP", Type: "character", Default: "';'"},
+				{Name: "G_DELIMS", Type: "string", Default: "\"(;)\""},
+				{Name: "G_OPEN", Type: "character", Default: "'('"},
+			},
+		},
+		{
+			"a : t_char := t_char'(';')", false,
+			[]InterfaceElement{
+				{Name: "a", Type: "t_char", Default: "t_char'(';')"},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		got, err := ParseInterfaceList(test.list, test.isPort)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("[%d]\ngot:  %+v\nwant: %+v", i, got, test.want)
+		}
+	}
+}
+
+func TestParseEntityLiterals(t *testing.T) {
+	code := `entity e is
+   generic (
+      G_QUOTE : character := '"'; -- Quote
+      G_OPEN  : character := '(';
+      G_CLOSE : character := ')'  -- Close
+   );
+   port (
+      a_i : in std_logic
+   );
+end entity;`
+
+	ent, err := ParseEntity(code)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	want := Entity{
+		Name: "e",
+		Generics: []InterfaceElement{
+			{Name: "G_QUOTE", Type: "character", Default: "'\"'"},
+			{Name: "G_OPEN", Type: "character", Default: "'('"},
+			{Name: "G_CLOSE", Type: "character", Default: "')'"},
+		},
+		Ports: []InterfaceElement{
+			{Name: "a_i", Mode: "in", Type: "std_logic"},
+		},
+	}
+	if !reflect.DeepEqual(ent, want) {
+		t.Errorf("\ngot:  %+v\nwant: %+v", ent, want)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	var tests = []struct {
+		code string
+		want string
+	}{
+		{"a <= b; -- comment", "a <= b; "},
+		{"s := \"--\"; -- comment", "s := \"--\"; "},
+		{"c := '\"'; -- \"comment\"", "c := '\"'; "},
+		{"c := '-'; -- comment", "c := '-'; "},
+		{"n := s'length; -- it's", "n := s'length; "},
+	}
+
+	for i, test := range tests {
+		if got := StripComments(test.code); got != test.want+"\n" {
+			t.Errorf("[%d]: got %q, want %q", i, got, test.want+"\n")
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package vhdl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EvalInt evaluates simple integer expression. Supported operators are: +, -, *, /, mod, rem, **
+// and parentheses. Lookup is called for identifiers, names are passed in lower case.
+// Selected names, for example 'pkg.const', are passed as a single identifier.
+func EvalInt(expr string, lookup func(name string) (int, error)) (int, error) {
+	p := exprParser{expr: strings.ToLower(expr), lookup: lookup}
+
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.expr) {
+		return 0, fmt.Errorf("unexpected '%s' in expression '%s'", p.expr[p.pos:], expr)
+	}
+
+	return v, nil
+}
+
+type exprParser struct {
+	expr   string
+	pos    int
+	lookup func(name string) (int, error)
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.expr) && (p.expr[p.pos] == ' ' || p.expr[p.pos] == '\t') {
+		p.pos += 1
+	}
+}
+
+// consume returns true and advances if the expression continues with the token.
+func (p *exprParser) consume(token string) bool {
+	p.skipSpaces()
+	if !strings.HasPrefix(p.expr[p.pos:], token) {
+		return false
+	}
+	// Keyword operators must not be prefixes of identifiers.
+	end := p.pos + len(token)
+	if isIdentRune(rune(token[0])) && end < len(p.expr) && isIdentRune(rune(p.expr[end])) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// parseExpr parses the leading sign and adding operators.
+// The sign applies to the first term, so it binds looser than multiplying operators.
+func (p *exprParser) parseExpr() (int, error) {
+	neg := p.consume("-")
+	if !neg {
+		p.consume("+")
+	}
+
+	v, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		if v == math.MinInt {
+			return 0, fmt.Errorf("integer overflow in expression '%s'", p.expr)
+		}
+		v = -v
+	}
+
+	for {
+		if p.consume("+") {
+			r, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v += r
+		} else if p.consume("-") {
+			r, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			v -= r
+		} else {
+			return v, nil
+		}
+	}
+}
+
+// parseTerm parses multiplying operators.
+func (p *exprParser) parseTerm() (int, error) {
+	v, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		var op string
+		if p.consume("**") {
+			// Exponentiation is handled in parseFactor, so this is an error.
+			return 0, fmt.Errorf("unexpected '**' in expression '%s'", p.expr)
+		} else if p.consume("*") {
+			op = "*"
+		} else if p.consume("/") {
+			op = "/"
+		} else if p.consume("mod") {
+			op = "mod"
+		} else if p.consume("rem") {
+			op = "rem"
+		} else {
+			return v, nil
+		}
+
+		r, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+
+		switch op {
+		case "*":
+			var ok bool
+			if v, ok = mul(v, r); !ok {
+				return 0, fmt.Errorf("integer overflow in expression '%s'", p.expr)
+			}
+		default:
+			if r == 0 {
+				return 0, fmt.Errorf("division by zero in expression '%s'", p.expr)
+			}
+			switch op {
+			case "/":
+				if v == math.MinInt && r == -1 {
+					return 0, fmt.Errorf("integer overflow in expression '%s'", p.expr)
+				}
+				v /= r
+			case "rem":
+				v %= r
+			case "mod":
+				v = ((v % r) + r) % r
+			}
+		}
+	}
+}
+
+// parseFactor parses exponentiation.
+func (p *exprParser) parseFactor() (int, error) {
+	v, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+
+	if p.consume("**") {
+		// Signed exponent is accepted only to report it as negative.
+		neg := p.consume("-")
+		if !neg {
+			p.consume("+")
+		}
+		e, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+		if neg {
+			e = -e
+		}
+		if e < 0 {
+			return 0, fmt.Errorf("negative exponent in expression '%s'", p.expr)
+		}
+		v, err = pow(v, e)
+		if err != nil {
+			return 0, fmt.Errorf("%v in expression '%s'", err, p.expr)
+		}
+	}
+
+	return v, nil
+}
+
+// pow computes base**exp with exponentiation by squaring.
+// The exponent must be non-negative.
+func pow(base, exp int) (int, error) {
+	var ok bool
+	r := 1
+	for exp > 0 {
+		if exp&1 == 1 {
+			if r, ok = mul(r, base); !ok {
+				return 0, fmt.Errorf("integer overflow")
+			}
+		}
+		exp >>= 1
+		if exp > 0 {
+			if base, ok = mul(base, base); !ok {
+				return 0, fmt.Errorf("integer overflow")
+			}
+		}
+	}
+	return r, nil
+}
+
+// mul returns a*b and false if the multiplication overflows.
+func mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+func (p *exprParser) parsePrimary() (int, error) {
+	p.skipSpaces()
+
+	if p.pos == len(p.expr) {
+		return 0, fmt.Errorf("unexpected end of expression '%s'", p.expr)
+	}
+
+	if p.consume("(") {
+		v, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if !p.consume(")") {
+			return 0, fmt.Errorf("missing ')' in expression '%s'", p.expr)
+		}
+		return v, nil
+	}
+
+	start := p.pos
+	if unicode.IsDigit(rune(p.expr[p.pos])) {
+		for p.pos < len(p.expr) && (isIdentRune(rune(p.expr[p.pos])) || p.expr[p.pos] == '#') {
+			p.pos += 1
+		}
+		return parseIntLiteral(p.expr[start:p.pos])
+	}
+
+	if unicode.IsLetter(rune(p.expr[p.pos])) {
+		for p.pos < len(p.expr) && (isIdentRune(rune(p.expr[p.pos])) || p.expr[p.pos] == '.') {
+			p.pos += 1
+		}
+		return p.lookup(p.expr[start:p.pos])
+	}
+
+	return 0, fmt.Errorf("unexpected '%s' in expression '%s'", p.expr[p.pos:], p.expr)
+}
+
+// parseIntLiteral parses decimal literal or based literal, for example '16#ff#'.
+func parseIntLiteral(lit string) (int, error) {
+	lit = strings.ReplaceAll(lit, "_", "")
+
+	base := 10
+	if strings.Count(lit, "#") == 2 && strings.HasSuffix(lit, "#") {
+		splits := strings.Split(lit, "#")
+		b, err := strconv.Atoi(splits[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid literal '%s'", lit)
+		}
+		base = b
+		lit = splits[1]
+	}
+
+	v, err := strconv.ParseInt(lit, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid literal '%s'", lit)
+	}
+
+	return int(v), nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,68 @@
+package vhdl
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEvalInt(t *testing.T) {
+	consts := map[string]int{"data_w": 32, "pkg.addr_w": 12, "n": 3}
+	lookup := func(name string) (int, error) {
+		if v, ok := consts[name]; ok {
+			return v, nil
+		}
+		return 0, fmt.Errorf("unknown identifier '%s'", name)
+	}
+
+	var tests = []struct {
+		expr string
+		val  int
+		err  string
+	}{
+		{expr: "7", val: 7},
+		{expr: "1_000", val: 1000},
+		{expr: "16#FF#", val: 255},
+		{expr: "DATA_W-1", val: 31},
+		{expr: "DATA_W / 8 - 1", val: 3},
+		{expr: "2**N - 1", val: 7},
+		{expr: "2 ** (N + 1)", val: 16},
+		{expr: "-2 * 3 + 10", val: 4},
+		{expr: "pkg.ADDR_W + 4", val: 16},
+		{expr: "(DATA_W + 7) / 8", val: 4},
+		{expr: "10 mod 4", val: 2},
+		{expr: "-7 rem 4", val: -3},
+		{expr: "-7 mod 4", val: -3},
+		{expr: "(-7) mod 4", val: 1},
+		{expr: "-5 mod 3", val: -2},
+		{expr: "+5 mod 3", val: 2},
+		{expr: "-2 ** 2", val: -4},
+		{expr: "2 * -3", err: "unexpected '-3' in expression '2 * -3'"},
+		{expr: "ADDR_W - 1", err: "unknown identifier 'addr_w'"},
+		{expr: "(3 + 4", err: "missing ')' in expression '(3 + 4'"},
+		{expr: "4 / 0", err: "division by zero in expression '4 / 0'"},
+		{expr: "2 ** 62", val: 1 << 62},
+		{expr: "(-3) ** 3", val: -27},
+		{expr: "0 ** 0", val: 1},
+		{expr: "2 ** -1", err: "negative exponent in expression '2 ** -1'"},
+		{expr: "2 ** 64", err: "integer overflow in expression '2 ** 64'"},
+		{expr: "3 ** 1_000_000_000", err: "integer overflow in expression '3 ** 1_000_000_000'"},
+		{expr: "2 ** 32 * 2 ** 32", err: "integer overflow in expression '2 ** 32 * 2 ** 32'"},
+		{expr: "(-9_223_372_036_854_775_807 - 1) / (-1)", err: "integer overflow in expression '(-9_223_372_036_854_775_807 - 1) / (-1)'"},
+		{expr: "log2(DATA_W)", err: "unknown identifier 'log2'"},
+	}
+
+	for i, test := range tests {
+		val, err := EvalInt(test.expr, lookup)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("[%d]: got error %v, want %s", i, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%d]: %v", i, err)
+		} else if val != test.val {
+			t.Errorf("[%d]: got %d, want %d", i, val, test.val)
+		}
+	}
+}
@@ -49,3 +49,14 @@ var StartsWithRoundBracket *re.Regexp = re.MustCompile(`(?i)^\s*(is\s+)?\(`)
 var VariableDeclaration *re.Regexp = re.MustCompile(`(?i)^\s*(shared)?\s*variable\s+(\w+)\b`)
 
 var SimpleRange *re.Regexp = re.MustCompile(`(?i)\s*(.+)\s+(downto|to)\s+(.+)\s*`)
+
+// Clock and reset naming heuristics, patterns expect lowercase names.
+var PositiveResetPattern string = `re?se?t((p)|(p_i)|(_p)|(_i)|(_p_i)|(_i_p))?\b`
+var NegativeResetPattern string = `re?se?t((n)|(n_i)|(_n)|(_n_i)|(_i_n))\b`
+
+var PositiveReset *re.Regexp = re.MustCompile(PositiveResetPattern)
+var NegativeReset *re.Regexp = re.MustCompile(NegativeResetPattern)
+
+var ClockFrequency *re.Regexp = re.MustCompile(`cl(oc)?k_?(\d+)`)
+var ClockName *re.Regexp = re.MustCompile(`([a-z0-9]+)_cl(oc)?k`)
+var ClockPort *re.Regexp = re.MustCompile(`(^|_)a?cl(oc)?k(_?\d+)?(_i)?$`)
@@ -12,8 +12,24 @@ for dir in $(find . -maxdepth 4 -mindepth 4 -type d);
 do
 	echo "    $dir"
 	cd $dir
-	../../../../../../thdl gen -to-stdout test.vhd > stdout || true
+	# Optional args file contains additional gen command flags.
+	# The -check and -diff flags replace the default -to-stdout mode.
+	args=""
+	mode="-to-stdout"
+	if [ -f args ]; then
+		args=$(cat args)
+		if echo " $args " | grep -qE -- " -(check|diff) "; then
+			mode=""
+		fi
+	fi
+	status=0
+	../../../../../../thdl gen $mode $args test.vhd > stdout || status=$?
 	diff --color stdout.golden.vhd stdout
+	# Optional status file contains the expected exit status.
+	if [ -f status ] && [ "$(cat status)" != "$status" ]; then
+		echo "got exit status $status, want $(cat status)"
+		exit 1
+	fi
 	rm stdout
 	cd ../../../..
 done
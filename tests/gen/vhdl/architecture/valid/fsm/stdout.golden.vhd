@@ -0,0 +1,78 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+entity e is
+   port (
+      clk_i : in std_logic
+   );
+end entity;
+
+architecture rtl of e is
+
+   function max(
+      a : integer;
+      b : integer
+   ) return integer is
+   begin
+      if a > b then
+         return a;
+      end if;
+      return b;
+   end function;
+
+   procedure nop;
+
+   --thdl:gen
+   type t_state is (IDLE, RUN, DONE);
+
+   signal state : t_state;
+
+   --thdl:start checksum=7199b0da
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(1 downto 0)) return t_state;
+   function to_slv(state : t_state) return std_logic_vector;
+   function to_str(state : t_state) return string;
+
+   function to_state(slv : std_logic_vector(1 downto 0)) return t_state is
+   begin
+      case slv is
+         when "00" => return IDLE;
+         when "01" => return RUN;
+         when "10" => return DONE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(state : t_state) return std_logic_vector is
+   begin
+      case state is
+         when IDLE => return "00";
+         when RUN => return "01";
+         when DONE => return "10";
+      end case;
+   end function;
+
+   function to_str(state : t_state) return string is
+   begin
+      case state is
+         when IDLE => return "IDLE";
+         when RUN => return "RUN";
+         when DONE => return "DONE";
+      end case;
+   end function;
+
+   --thdl:end
+
+begin
+
+   process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         report to_str(state);
+      end if;
+   end process;
+
+end architecture;
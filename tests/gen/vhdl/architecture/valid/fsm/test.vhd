@@ -0,0 +1,39 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+entity e is
+   port (
+      clk_i : in std_logic
+   );
+end entity;
+
+architecture rtl of e is
+
+   function max(
+      a : integer;
+      b : integer
+   ) return integer is
+   begin
+      if a > b then
+         return a;
+      end if;
+      return b;
+   end function;
+
+   procedure nop;
+
+   --thdl:gen
+   type t_state is (IDLE, RUN, DONE);
+
+   signal state : t_state;
+
+begin
+
+   process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         report to_str(state);
+      end if;
+   end process;
+
+end architecture;
@@ -0,0 +1,166 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen
+   type t_status is (OK, ERR, BUSY);
+
+   --thdl:gen
+   type t_status_arr is array (0 to 2) of t_status;
+
+   --thdl:gen order=lsb-first
+   type t_bytes is array (3 downto 0) of std_logic_vector(7 downto 0);
+
+   --thdl:gen
+   type t_header is record
+      status : t_status_arr;
+      data   : t_bytes;
+      valid  : std_logic;
+   end record;
+
+   --thdl:start checksum=319d308e
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_status(slv : std_logic_vector(1 downto 0)) return t_status;
+   function to_slv(status : t_status) return std_logic_vector;
+   function to_str(status : t_status) return string;
+
+   function to_status_arr(slv : std_logic_vector(5 downto 0)) return t_status_arr;
+   function to_slv(status_arr : t_status_arr) return std_logic_vector;
+   function to_str(status_arr : t_status_arr) return string;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes;
+   function to_slv(bytes : t_bytes) return std_logic_vector;
+   function to_str(bytes : t_bytes) return string;
+
+   constant C_HEADER_WIDTH : natural := 39;
+   constant C_HEADER_STATUS_HI : natural := 38;
+   constant C_HEADER_STATUS_LO : natural := 33;
+   constant C_HEADER_DATA_HI : natural := 32;
+   constant C_HEADER_DATA_LO : natural := 1;
+   constant C_HEADER_VALID_HI : natural := 0;
+   constant C_HEADER_VALID_LO : natural := 0;
+
+   function to_header(slv : std_logic_vector(38 downto 0)) return t_header;
+   function to_slv(header : t_header) return std_logic_vector;
+   function to_str(header : t_header; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=a24441b8
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_status(slv : std_logic_vector(1 downto 0)) return t_status is
+   begin
+      case slv is
+         when "00" => return OK;
+         when "01" => return ERR;
+         when "10" => return BUSY;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(status : t_status) return std_logic_vector is
+   begin
+      case status is
+         when OK => return "00";
+         when ERR => return "01";
+         when BUSY => return "10";
+      end case;
+   end function;
+
+   function to_str(status : t_status) return string is
+   begin
+      case status is
+         when OK => return "OK";
+         when ERR => return "ERR";
+         when BUSY => return "BUSY";
+      end case;
+   end function;
+
+   function to_status_arr(slv : std_logic_vector(5 downto 0)) return t_status_arr is
+      variable status_arr : t_status_arr;
+   begin
+      status_arr(0) := to_status(slv(5 downto 4));
+      status_arr(1) := to_status(slv(3 downto 2));
+      status_arr(2) := to_status(slv(1 downto 0));
+      return status_arr;
+   end function;
+
+   function to_slv(status_arr : t_status_arr) return std_logic_vector is
+      variable slv : std_logic_vector(5 downto 0);
+   begin
+      slv(5 downto 4) := to_slv(status_arr(0));
+      slv(3 downto 2) := to_slv(status_arr(1));
+      slv(1 downto 0) := to_slv(status_arr(2));
+      return slv;
+   end function;
+
+   function to_str(status_arr : t_status_arr) return string is
+   begin
+      return "(" & to_str(status_arr(0)) & ", " & to_str(status_arr(1)) & ", " & to_str(status_arr(2)) & ")";
+   end function;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes is
+      variable bytes : t_bytes;
+   begin
+      bytes(0) := slv(31 downto 24);
+      bytes(1) := slv(23 downto 16);
+      bytes(2) := slv(15 downto 8);
+      bytes(3) := slv(7 downto 0);
+      return bytes;
+   end function;
+
+   function to_slv(bytes : t_bytes) return std_logic_vector is
+      variable slv : std_logic_vector(31 downto 0);
+   begin
+      slv(31 downto 24) := bytes(0);
+      slv(23 downto 16) := bytes(1);
+      slv(15 downto 8) := bytes(2);
+      slv(7 downto 0) := bytes(3);
+      return slv;
+   end function;
+
+   function to_str(bytes : t_bytes) return string is
+   begin
+      return "(" & to_string(bytes(3)) & ", " & to_string(bytes(2)) & ", " & to_string(bytes(1)) & ", " & to_string(bytes(0)) & ")";
+   end function;
+
+   function to_header(slv : std_logic_vector(38 downto 0)) return t_header is
+      variable header : t_header;
+   begin
+      header.status := to_status_arr(slv(38 downto 33));
+      header.data := to_bytes(slv(32 downto 1));
+      header.valid := slv(0);
+      return header;
+   end function;
+
+   function to_slv(header : t_header) return std_logic_vector is
+      variable slv : std_logic_vector(38 downto 0);
+   begin
+      slv(38 downto 33) := to_slv(header.status);
+      slv(32 downto 1) := to_slv(header.data);
+      slv(0) := header.valid;
+      return slv;
+   end function;
+
+   function to_str(header : t_header; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "status => " & to_str(header.status) & ", " & "data => " & to_str(header.data) & ", " & "valid => " & to_string(header.valid) & ")";
+      end if;
+      return "(" & to_str(header.status) & ", " & to_str(header.data) & ", " & to_string(header.valid) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,24 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen
+   type t_status is (OK, ERR, BUSY);
+
+   --thdl:gen
+   type t_status_arr is array (0 to 2) of t_status;
+
+   --thdl:gen order=lsb-first
+   type t_bytes is array (3 downto 0) of std_logic_vector(7 downto 0);
+
+   --thdl:gen
+   type t_header is record
+      status : t_status_arr;
+      data   : t_bytes;
+      valid  : std_logic;
+   end record;
+end package;
+
+package body p is
+end package body;
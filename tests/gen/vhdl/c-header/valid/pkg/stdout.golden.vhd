@@ -0,0 +1,211 @@
+/*
+ * Below code was automatically generated with the thdl tool.
+ * Do not modify it by hand, unless you really know what you do.
+ * More info on https://github.com/m-kru/go-thdl.
+ *
+ * Source: VHDL package 'regs'.
+ */
+
+#ifndef REGS_H
+#define REGS_H
+
+#include <stdint.h>
+
+/* Enumeration type t_state, one-hot encoding. */
+#define STATE_WIDTH 3
+
+enum state {
+	STATE_IDLE = 0x1,
+	STATE_RUN = 0x2,
+	STATE_DONE = 0x4,
+};
+
+/* Record type t_ctrl. */
+#define CTRL_WIDTH 64
+#define CTRL_EN_SHIFT 0
+#define CTRL_EN_WIDTH 1
+#define CTRL_EN_MASK UINT64_C(0x0000000000000001)
+#define CTRL_STATE_SHIFT 1
+#define CTRL_STATE_WIDTH 3
+#define CTRL_STATE_MASK UINT64_C(0x000000000000000e)
+#define CTRL_LEN_SHIFT 8
+#define CTRL_LEN_WIDTH 8
+#define CTRL_LEN_MASK UINT64_C(0x000000000000ff00)
+#define CTRL_OFS_SHIFT 16
+#define CTRL_OFS_WIDTH 32
+#define CTRL_OFS_MASK UINT64_C(0x0000ffffffff0000)
+
+struct ctrl {
+	uint8_t en;
+	uint8_t state;
+	uint8_t len;
+	int32_t ofs;
+};
+
+static inline uint64_t ctrl_pack(const struct ctrl *ctrl)
+{
+	uint64_t word = 0;
+
+	word |= ((uint64_t)ctrl->en << CTRL_EN_SHIFT) & CTRL_EN_MASK;
+	word |= ((uint64_t)ctrl->state << CTRL_STATE_SHIFT) & CTRL_STATE_MASK;
+	word |= ((uint64_t)ctrl->len << CTRL_LEN_SHIFT) & CTRL_LEN_MASK;
+	word |= ((uint64_t)(uint32_t)ctrl->ofs << CTRL_OFS_SHIFT) & CTRL_OFS_MASK;
+
+	return word;
+}
+
+static inline void ctrl_unpack(uint64_t word, struct ctrl *ctrl)
+{
+	ctrl->en = (uint8_t)((word & CTRL_EN_MASK) >> CTRL_EN_SHIFT);
+	ctrl->state = (uint8_t)((word & CTRL_STATE_MASK) >> CTRL_STATE_SHIFT);
+	ctrl->len = (uint8_t)((word & CTRL_LEN_MASK) >> CTRL_LEN_SHIFT);
+	ctrl->ofs = (int32_t)(uint32_t)((word & CTRL_OFS_MASK) >> CTRL_OFS_SHIFT);
+}
+
+/* Array type t_bytes. */
+#define BYTES_WIDTH 32
+#define BYTES_LENGTH 4
+#define BYTES_ELEMENT_WIDTH 8
+
+#endif /* REGS_H */
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package regs is
+   --thdl:gen encoding=one-hot
+   type t_state is (IDLE, RUN, DONE);
+
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      state : t_state; --thdl: pad=4
+      len   : unsigned(7 downto 0);
+      ofs   : integer;
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 3) of std_logic_vector(7 downto 0);
+
+   --thdl:start checksum=42c73aa4
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(2 downto 0)) return t_state;
+   function to_slv(state : t_state) return std_logic_vector;
+   function to_str(state : t_state) return string;
+
+   constant C_CTRL_WIDTH : natural := 64;
+   constant C_CTRL_EN_HI : natural := 0;
+   constant C_CTRL_EN_LO : natural := 0;
+   constant C_CTRL_STATE_HI : natural := 3;
+   constant C_CTRL_STATE_LO : natural := 1;
+   constant C_CTRL_LEN_HI : natural := 15;
+   constant C_CTRL_LEN_LO : natural := 8;
+   constant C_CTRL_OFS_HI : natural := 47;
+   constant C_CTRL_OFS_LO : natural := 16;
+
+   function to_ctrl(slv : std_logic_vector(63 downto 0)) return t_ctrl;
+   function to_slv(ctrl : t_ctrl) return std_logic_vector;
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes;
+   function to_slv(bytes : t_bytes) return std_logic_vector;
+   function to_str(bytes : t_bytes) return string;
+
+   --thdl:end
+
+end package;
+
+package body regs is
+
+   --thdl:start checksum=2c4a817f
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(2 downto 0)) return t_state is
+   begin
+      case slv is
+         when "001" => return IDLE;
+         when "010" => return RUN;
+         when "100" => return DONE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(state : t_state) return std_logic_vector is
+   begin
+      case state is
+         when IDLE => return "001";
+         when RUN => return "010";
+         when DONE => return "100";
+      end case;
+   end function;
+
+   function to_str(state : t_state) return string is
+   begin
+      case state is
+         when IDLE => return "IDLE";
+         when RUN => return "RUN";
+         when DONE => return "DONE";
+      end case;
+   end function;
+
+   function to_ctrl(slv : std_logic_vector(63 downto 0)) return t_ctrl is
+      variable ctrl : t_ctrl;
+   begin
+      ctrl.en := slv(0);
+      ctrl.state := to_state(slv(3 downto 1));
+      ctrl.len := unsigned(slv(15 downto 8));
+      ctrl.ofs := to_integer(signed(slv(47 downto 16)));
+      return ctrl;
+   end function;
+
+   function to_slv(ctrl : t_ctrl) return std_logic_vector is
+      variable slv : std_logic_vector(63 downto 0) := (others => '0');
+   begin
+      slv(0) := ctrl.en;
+      slv(3 downto 1) := to_slv(ctrl.state);
+      slv(15 downto 8) := std_logic_vector(ctrl.len);
+      slv(47 downto 16) := std_logic_vector(to_signed(ctrl.ofs, 32));
+      return slv;
+   end function;
+
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "en => " & to_string(ctrl.en) & ", " & "state => " & to_str(ctrl.state) & ", " & "len => " & to_string(ctrl.len) & ", " & "ofs => " & to_string(ctrl.ofs) & ")";
+      end if;
+      return "(" & to_string(ctrl.en) & ", " & to_str(ctrl.state) & ", " & to_string(ctrl.len) & ", " & to_string(ctrl.ofs) & ")";
+   end function;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes is
+      variable bytes : t_bytes;
+   begin
+      bytes(0) := slv(31 downto 24);
+      bytes(1) := slv(23 downto 16);
+      bytes(2) := slv(15 downto 8);
+      bytes(3) := slv(7 downto 0);
+      return bytes;
+   end function;
+
+   function to_slv(bytes : t_bytes) return std_logic_vector is
+      variable slv : std_logic_vector(31 downto 0);
+   begin
+      slv(31 downto 24) := bytes(0);
+      slv(23 downto 16) := bytes(1);
+      slv(15 downto 8) := bytes(2);
+      slv(7 downto 0) := bytes(3);
+      return slv;
+   end function;
+
+   function to_str(bytes : t_bytes) return string is
+   begin
+      return "(" & to_string(bytes(0)) & ", " & to_string(bytes(1)) & ", " & to_string(bytes(2)) & ", " & to_string(bytes(3)) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
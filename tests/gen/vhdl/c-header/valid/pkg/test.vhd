@@ -0,0 +1,22 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package regs is
+   --thdl:gen encoding=one-hot
+   type t_state is (IDLE, RUN, DONE);
+
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      state : t_state; --thdl: pad=4
+      len   : unsigned(7 downto 0);
+      ofs   : integer;
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 3) of std_logic_vector(7 downto 0);
+end package;
+
+package body regs is
+end package body;
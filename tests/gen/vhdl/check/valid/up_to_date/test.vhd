@@ -0,0 +1,52 @@
+package p is
+   --thdl:gen
+   type t_state is (IDLE, RUN);
+
+   --thdl:start checksum=72266c5e
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(0 downto 0)) return t_state;
+   function to_slv(state : t_state) return std_logic_vector;
+   function to_str(state : t_state) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=22c3d94c
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(0 downto 0)) return t_state is
+   begin
+      case slv is
+         when "0" => return IDLE;
+         when "1" => return RUN;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(state : t_state) return std_logic_vector is
+   begin
+      case state is
+         when IDLE => return "0";
+         when RUN => return "1";
+      end case;
+   end function;
+
+   function to_str(state : t_state) return string is
+   begin
+      case state is
+         when IDLE => return "IDLE";
+         when RUN => return "RUN";
+      end case;
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,59 @@
+--- a/test.vhd
++++ b/test.vhd
+@@ -2,12 +2,12 @@
+    --thdl:gen
+    type t_state is (IDLE, RUN, DONE);
+ 
+-   --thdl:start checksum=72266c5e
++   --thdl:start checksum=25b1bbc9
+    -- Below code was automatically generated with the thdl tool.
+    -- Do not modify it by hand, unless you really know what you do.
+    -- More info on https://github.com/m-kru/go-thdl.
+ 
+-   function to_state(slv : std_logic_vector(0 downto 0)) return t_state;
++   function to_state(slv : std_logic_vector(1 downto 0)) return t_state;
+    function to_slv(state : t_state) return std_logic_vector;
+    function to_str(state : t_state) return string;
+ 
+@@ -17,16 +17,17 @@
+ 
+ package body p is
+ 
+-   --thdl:start checksum=22c3d94c
++   --thdl:start checksum=1f297343
+    -- Below code was automatically generated with the thdl tool.
+    -- Do not modify it by hand, unless you really know what you do.
+    -- More info on https://github.com/m-kru/go-thdl.
+ 
+-   function to_state(slv : std_logic_vector(0 downto 0)) return t_state is
++   function to_state(slv : std_logic_vector(1 downto 0)) return t_state is
+    begin
+       case slv is
+-         when "0" => return IDLE;
+-         when "1" => return RUN;
++         when "00" => return IDLE;
++         when "01" => return RUN;
++         when "10" => return DONE;
+          when others => report "invalid slv value " & to_string(slv) severity failure;
+       end case;
+    end function;
+@@ -34,8 +35,9 @@
+    function to_slv(state : t_state) return std_logic_vector is
+    begin
+       case state is
+-         when IDLE => return "0";
+-         when RUN => return "1";
++         when IDLE => return "00";
++         when RUN => return "01";
++         when DONE => return "10";
+       end case;
+    end function;
+ 
+@@ -44,6 +46,7 @@
+       case state is
+          when IDLE => return "IDLE";
+          when RUN => return "RUN";
++         when DONE => return "DONE";
+       end case;
+    end function;
+ 
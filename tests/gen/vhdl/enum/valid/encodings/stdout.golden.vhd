@@ -0,0 +1,137 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen encoding=gray
+   type t_gray is (S0, S1, S2, S3);
+
+   --thdl:gen encoding=johnson
+   type t_johnson is (J0, J1, J2, J3, J4);
+
+   --thdl:gen encoding=one-cold
+   type t_one_cold is (C0, C1, C2);
+
+   --thdl:start checksum=f7bcb614
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_gray(slv : std_logic_vector(1 downto 0)) return t_gray;
+   function to_slv(gray : t_gray) return std_logic_vector;
+   function to_str(gray : t_gray) return string;
+
+   function to_johnson(slv : std_logic_vector(2 downto 0)) return t_johnson;
+   function to_slv(johnson : t_johnson) return std_logic_vector;
+   function to_str(johnson : t_johnson) return string;
+
+   function to_one_cold(slv : std_logic_vector(2 downto 0)) return t_one_cold;
+   function to_slv(one_cold : t_one_cold) return std_logic_vector;
+   function to_str(one_cold : t_one_cold) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=e45f5ad1
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_gray(slv : std_logic_vector(1 downto 0)) return t_gray is
+   begin
+      case slv is
+         when "00" => return S0;
+         when "01" => return S1;
+         when "11" => return S2;
+         when "10" => return S3;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(gray : t_gray) return std_logic_vector is
+   begin
+      case gray is
+         when S0 => return "00";
+         when S1 => return "01";
+         when S2 => return "11";
+         when S3 => return "10";
+      end case;
+   end function;
+
+   function to_str(gray : t_gray) return string is
+   begin
+      case gray is
+         when S0 => return "S0";
+         when S1 => return "S1";
+         when S2 => return "S2";
+         when S3 => return "S3";
+      end case;
+   end function;
+
+   function to_johnson(slv : std_logic_vector(2 downto 0)) return t_johnson is
+   begin
+      case slv is
+         when "000" => return J0;
+         when "001" => return J1;
+         when "011" => return J2;
+         when "111" => return J3;
+         when "110" => return J4;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(johnson : t_johnson) return std_logic_vector is
+   begin
+      case johnson is
+         when J0 => return "000";
+         when J1 => return "001";
+         when J2 => return "011";
+         when J3 => return "111";
+         when J4 => return "110";
+      end case;
+   end function;
+
+   function to_str(johnson : t_johnson) return string is
+   begin
+      case johnson is
+         when J0 => return "J0";
+         when J1 => return "J1";
+         when J2 => return "J2";
+         when J3 => return "J3";
+         when J4 => return "J4";
+      end case;
+   end function;
+
+   function to_one_cold(slv : std_logic_vector(2 downto 0)) return t_one_cold is
+   begin
+      case slv is
+         when "110" => return C0;
+         when "101" => return C1;
+         when "011" => return C2;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(one_cold : t_one_cold) return std_logic_vector is
+   begin
+      case one_cold is
+         when C0 => return "110";
+         when C1 => return "101";
+         when C2 => return "011";
+      end case;
+   end function;
+
+   function to_str(one_cold : t_one_cold) return string is
+   begin
+      case one_cold is
+         when C0 => return "C0";
+         when C1 => return "C1";
+         when C2 => return "C2";
+      end case;
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,16 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen encoding=gray
+   type t_gray is (S0, S1, S2, S3);
+
+   --thdl:gen encoding=johnson
+   type t_johnson is (J0, J1, J2, J3, J4);
+
+   --thdl:gen encoding=one-cold
+   type t_one_cold is (C0, C1, C2);
+end package;
+
+package body p is
+end package body;
@@ -0,0 +1,62 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen encoding=explicit width=8
+   type t_opcode is (
+      NOP,   --thdl: value=0x00
+      LOAD,  --thdl: value=0x1A
+      STORE  --thdl: value=0x2B
+   );
+
+   --thdl:start checksum=56476132
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_opcode(slv : std_logic_vector(7 downto 0)) return t_opcode;
+   function to_slv(opcode : t_opcode) return std_logic_vector;
+   function to_str(opcode : t_opcode) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=5b9b8dfa
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_opcode(slv : std_logic_vector(7 downto 0)) return t_opcode is
+   begin
+      case slv is
+         when "00000000" => return NOP;
+         when "00011010" => return LOAD;
+         when "00101011" => return STORE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(opcode : t_opcode) return std_logic_vector is
+   begin
+      case opcode is
+         when NOP => return "00000000";
+         when LOAD => return "00011010";
+         when STORE => return "00101011";
+      end case;
+   end function;
+
+   function to_str(opcode : t_opcode) return string is
+   begin
+      case opcode is
+         when NOP => return "NOP";
+         when LOAD => return "LOAD";
+         when STORE => return "STORE";
+      end case;
+   end function;
+
+   --thdl:end
+
+end package body;
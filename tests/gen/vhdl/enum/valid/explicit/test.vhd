@@ -0,0 +1,14 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen encoding=explicit width=8
+   type t_opcode is (
+      NOP,   --thdl: value=0x00
+      LOAD,  --thdl: value=0x1A
+      STORE  --thdl: value=0x2B
+   );
+end package;
+
+package body p is
+end package body;
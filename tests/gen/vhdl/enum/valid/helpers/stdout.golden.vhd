@@ -0,0 +1,104 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen count from-str is-valid succ to-int
+   type t_state is (IDLE, RUN, DONE);
+
+   --thdl:start checksum=571733a7
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_STATE_COUNT : natural := 3;
+
+   function to_state(slv : std_logic_vector(1 downto 0)) return t_state;
+   function to_slv(state : t_state) return std_logic_vector;
+   function to_str(state : t_state) return string;
+   function from_str(s : string) return t_state;
+   function is_valid_state(slv : std_logic_vector(1 downto 0)) return boolean;
+   function succ(state : t_state) return t_state;
+   function pred(state : t_state) return t_state;
+   function to_integer(state : t_state) return natural;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=5a488aae
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(1 downto 0)) return t_state is
+   begin
+      case slv is
+         when "00" => return IDLE;
+         when "01" => return RUN;
+         when "10" => return DONE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(state : t_state) return std_logic_vector is
+   begin
+      case state is
+         when IDLE => return "00";
+         when RUN => return "01";
+         when DONE => return "10";
+      end case;
+   end function;
+
+   function to_str(state : t_state) return string is
+   begin
+      case state is
+         when IDLE => return "IDLE";
+         when RUN => return "RUN";
+         when DONE => return "DONE";
+      end case;
+   end function;
+
+   function from_str(s : string) return t_state is
+   begin
+      if s = "IDLE" then return IDLE;
+      elsif s = "RUN" then return RUN;
+      elsif s = "DONE" then return DONE;
+      end if;
+      report "cannot convert '" & s & "' to t_state" severity failure;
+      return t_state'left;
+   end function;
+
+   function is_valid_state(slv : std_logic_vector(1 downto 0)) return boolean is
+   begin
+      case slv is
+         when "00" | "01" | "10" => return true;
+         when others => return false;
+      end case;
+   end function;
+
+   function succ(state : t_state) return t_state is
+   begin
+      if state = t_state'right then
+         return t_state'left;
+      end if;
+      return t_state'succ(state);
+   end function;
+
+   function pred(state : t_state) return t_state is
+   begin
+      if state = t_state'left then
+         return t_state'right;
+      end if;
+      return t_state'pred(state);
+   end function;
+
+   function to_integer(state : t_state) return natural is
+   begin
+      return t_state'pos(state);
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,10 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen count from-str is-valid succ to-int
+   type t_state is (IDLE, RUN, DONE);
+end package;
+
+package body p is
+end package body;
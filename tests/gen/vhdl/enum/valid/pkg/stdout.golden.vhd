@@ -5,7 +5,7 @@ package p1 is
    --thdl:gen
    type t_enum is (ZERO, ONE, TWO);
 
-   --thdl:start
+   --thdl:start checksum=a5e190ad
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -20,7 +20,7 @@ end package;
 
 package body p1 is
 
-   --thdl:start
+   --thdl:start checksum=0115937c
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -68,7 +68,7 @@ package P2 is
       THREE
    );
 
-   --thdl:start
+   --thdl:start checksum=a51caf9b
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -83,7 +83,7 @@ end package;
 
 package body p2 is
 
-   --thdl:start
+   --thdl:start checksum=cc94dce2
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
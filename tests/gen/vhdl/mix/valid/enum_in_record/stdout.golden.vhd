@@ -9,7 +9,7 @@ package p is
       e : t_enum;
    end record;
 
-   --thdl:start
+   --thdl:start checksum=433a6d3a
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -18,6 +18,10 @@ package p is
    function to_slv(enum : t_enum) return std_logic_vector;
    function to_str(enum : t_enum) return string;
 
+   constant C_REC_WIDTH : natural := 2;
+   constant C_REC_E_HI : natural := 1;
+   constant C_REC_E_LO : natural := 0;
+
    function to_rec(slv : std_logic_vector(1 downto 0)) return t_rec;
    function to_slv(rec : t_rec) return std_logic_vector;
 
@@ -27,7 +31,7 @@ end package;
 
 package body p is
 
-   --thdl:start
+   --thdl:start checksum=ac62f3e2
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
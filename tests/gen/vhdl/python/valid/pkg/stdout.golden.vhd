@@ -0,0 +1,272 @@
+# Below code was automatically generated with the thdl tool.
+# Do not modify it by hand, unless you really know what you do.
+# More info on https://github.com/m-kru/go-thdl.
+#
+# Source: VHDL package 'pkt'.
+
+from dataclasses import dataclass, field
+from enum import IntEnum
+
+
+def _to_signed(val: int, width: int) -> int:
+    if val >> (width - 1):
+        return val - (1 << width)
+    return val
+
+
+KIND_WIDTH = 2
+
+
+class Kind(IntEnum):
+    """Enumeration type t_kind, sequential encoding."""
+
+    DATA = 0x0
+    CTRL = 0x1
+    IDLE = 0x2
+
+
+HDR_WIDTH = 11
+
+
+@dataclass
+class Hdr:
+    """Record type t_hdr."""
+
+    kind: Kind = Kind.DATA
+    last: bool = False
+    len: int = 0
+
+    def to_int(self) -> int:
+        """Returns the value packed the same way as by the VHDL to_slv function."""
+        val = 0
+        val |= (int(self.kind) & 0x3) << 9
+        val |= (int(self.last) & 0x1) << 8
+        val |= (int(self.len) & 0xff) << 0
+        return val
+
+    @classmethod
+    def from_int(cls, val: int) -> "Hdr":
+        """Returns the record unpacked the same way as by the VHDL to_hdr function."""
+        return cls(
+            kind=Kind((val >> 9) & 0x3),
+            last=bool((val >> 8) & 0x1),
+            len=(val >> 0) & 0xff,
+        )
+
+
+BEAT_WIDTH = 64
+
+
+@dataclass
+class Beat:
+    """Record type t_beat."""
+
+    hdr: Hdr = field(default_factory=Hdr)
+    ofs: int = 0
+    valid: int = 0
+
+    def to_int(self) -> int:
+        """Returns the value packed the same way as by the VHDL to_slv function."""
+        val = 0
+        val |= (self.hdr.to_int() & 0x7ff) << 0
+        val |= (int(self.ofs) & 0xffffffff) << 16
+        val |= (int(self.valid) & 0x1) << 48
+        return val
+
+    @classmethod
+    def from_int(cls, val: int) -> "Beat":
+        """Returns the record unpacked the same way as by the VHDL to_beat function."""
+        return cls(
+            hdr=Hdr.from_int((val >> 0) & 0x7ff),
+            ofs=_to_signed((val >> 16) & 0xffffffff, 32),
+            valid=(val >> 48) & 0x1,
+        )
+
+
+# Array type t_beats.
+BEATS_WIDTH = 128
+BEATS_LENGTH = 2
+BEATS_ELEMENT_WIDTH = 64
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package pkt is
+   --thdl:gen
+   type t_kind is (DATA, CTRL, IDLE);
+
+   --thdl:gen
+   type t_hdr is record
+      kind  : t_kind;
+      last  : boolean;
+      len   : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen order=lsb-first align=64
+   type t_beat is record
+      hdr   : t_hdr; --thdl: pad=5
+      ofs   : integer;
+      valid : std_logic;
+   end record;
+
+   --thdl:gen
+   type t_beats is array (0 to 1) of t_beat;
+
+   --thdl:start checksum=f1f9e998
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(1 downto 0)) return t_kind;
+   function to_slv(kind : t_kind) return std_logic_vector;
+   function to_str(kind : t_kind) return string;
+
+   constant C_HDR_WIDTH : natural := 11;
+   constant C_HDR_KIND_HI : natural := 10;
+   constant C_HDR_KIND_LO : natural := 9;
+   constant C_HDR_LAST_HI : natural := 8;
+   constant C_HDR_LAST_LO : natural := 8;
+   constant C_HDR_LEN_HI : natural := 7;
+   constant C_HDR_LEN_LO : natural := 0;
+
+   function to_hdr(slv : std_logic_vector(10 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+
+   constant C_BEAT_WIDTH : natural := 64;
+   constant C_BEAT_HDR_HI : natural := 10;
+   constant C_BEAT_HDR_LO : natural := 0;
+   constant C_BEAT_OFS_HI : natural := 47;
+   constant C_BEAT_OFS_LO : natural := 16;
+   constant C_BEAT_VALID_HI : natural := 48;
+   constant C_BEAT_VALID_LO : natural := 48;
+
+   function to_beat(slv : std_logic_vector(63 downto 0)) return t_beat;
+   function to_slv(beat : t_beat) return std_logic_vector;
+   function to_str(beat : t_beat; add_names : boolean := false) return string;
+
+   function to_beats(slv : std_logic_vector(127 downto 0)) return t_beats;
+   function to_slv(beats : t_beats) return std_logic_vector;
+   function to_str(beats : t_beats) return string;
+
+   --thdl:end
+
+end package;
+
+package body pkt is
+
+   --thdl:start checksum=f1cace2a
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(1 downto 0)) return t_kind is
+   begin
+      case slv is
+         when "00" => return DATA;
+         when "01" => return CTRL;
+         when "10" => return IDLE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(kind : t_kind) return std_logic_vector is
+   begin
+      case kind is
+         when DATA => return "00";
+         when CTRL => return "01";
+         when IDLE => return "10";
+      end case;
+   end function;
+
+   function to_str(kind : t_kind) return string is
+   begin
+      case kind is
+         when DATA => return "DATA";
+         when CTRL => return "CTRL";
+         when IDLE => return "IDLE";
+      end case;
+   end function;
+
+   function to_hdr(slv : std_logic_vector(10 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.kind := to_kind(slv(10 downto 9));
+      if slv(8) = '1' then
+         hdr.last := true;
+      elsif slv(8) = '0' then
+         hdr.last := false;
+      else
+         report "bit 8: cannot convert " & to_string(slv(8)) & " to boolean type" severity failure;
+      end if;
+      hdr.len := unsigned(slv(7 downto 0));
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(10 downto 0);
+   begin
+      slv(10 downto 9) := to_slv(hdr.kind);
+      if hdr.last then slv(8) := '1'; else slv(8) := '0'; end if;
+      slv(7 downto 0) := std_logic_vector(hdr.len);
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "kind => " & to_str(hdr.kind) & ", " & "last => " & to_string(hdr.last) & ", " & "len => " & to_string(hdr.len) & ")";
+      end if;
+      return "(" & to_str(hdr.kind) & ", " & to_string(hdr.last) & ", " & to_string(hdr.len) & ")";
+   end function;
+
+   function to_beat(slv : std_logic_vector(63 downto 0)) return t_beat is
+      variable beat : t_beat;
+   begin
+      beat.hdr := to_hdr(slv(10 downto 0));
+      beat.ofs := to_integer(signed(slv(47 downto 16)));
+      beat.valid := slv(48);
+      return beat;
+   end function;
+
+   function to_slv(beat : t_beat) return std_logic_vector is
+      variable slv : std_logic_vector(63 downto 0) := (others => '0');
+   begin
+      slv(10 downto 0) := to_slv(beat.hdr);
+      slv(47 downto 16) := std_logic_vector(to_signed(beat.ofs, 32));
+      slv(48) := beat.valid;
+      return slv;
+   end function;
+
+   function to_str(beat : t_beat; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "hdr => " & to_str(beat.hdr) & ", " & "ofs => " & to_string(beat.ofs) & ", " & "valid => " & to_string(beat.valid) & ")";
+      end if;
+      return "(" & to_str(beat.hdr) & ", " & to_string(beat.ofs) & ", " & to_string(beat.valid) & ")";
+   end function;
+
+   function to_beats(slv : std_logic_vector(127 downto 0)) return t_beats is
+      variable beats : t_beats;
+   begin
+      beats(0) := to_beat(slv(127 downto 64));
+      beats(1) := to_beat(slv(63 downto 0));
+      return beats;
+   end function;
+
+   function to_slv(beats : t_beats) return std_logic_vector is
+      variable slv : std_logic_vector(127 downto 0);
+   begin
+      slv(127 downto 64) := to_slv(beats(0));
+      slv(63 downto 0) := to_slv(beats(1));
+      return slv;
+   end function;
+
+   function to_str(beats : t_beats) return string is
+   begin
+      return "(" & to_str(beats(0)) & ", " & to_str(beats(1)) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
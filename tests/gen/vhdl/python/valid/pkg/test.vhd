@@ -0,0 +1,28 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package pkt is
+   --thdl:gen
+   type t_kind is (DATA, CTRL, IDLE);
+
+   --thdl:gen
+   type t_hdr is record
+      kind  : t_kind;
+      last  : boolean;
+      len   : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen order=lsb-first align=64
+   type t_beat is record
+      hdr   : t_hdr; --thdl: pad=5
+      ofs   : integer;
+      valid : std_logic;
+   end record;
+
+   --thdl:gen
+   type t_beats is array (0 to 1) of t_beat;
+end package;
+
+package body pkt is
+end package body;
@@ -0,0 +1,13 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package a is
+   --thdl:gen
+   type t_mode is (IDLE, RUN, HALT);
+
+   --thdl:gen
+   type t_status is record
+      busy : std_logic;
+      errs : std_logic_vector(2 downto 0);
+   end record;
+end package;
@@ -0,0 +1,109 @@
+# Below code was automatically generated with the thdl tool.
+# Do not modify it by hand, unless you really know what you do.
+# More info on https://github.com/m-kru/go-thdl.
+#
+# Source: VHDL package 'p'.
+
+from dataclasses import dataclass, field
+
+from a import Status, Mode
+
+
+REC_WIDTH = 7
+
+
+@dataclass
+class Rec:
+    """Record type t_rec."""
+
+    status: Status = field(default_factory=Status)
+    mode: Mode = Mode.IDLE
+    en: int = 0
+
+    def to_int(self) -> int:
+        """Returns the value packed the same way as by the VHDL to_slv function."""
+        val = 0
+        val |= (self.status.to_int() & 0xf) << 3
+        val |= (int(self.mode) & 0x3) << 1
+        val |= (int(self.en) & 0x1) << 0
+        return val
+
+    @classmethod
+    def from_int(cls, val: int) -> "Rec":
+        """Returns the record unpacked the same way as by the VHDL to_rec function."""
+        return cls(
+            status=Status.from_int((val >> 3) & 0xf),
+            mode=Mode((val >> 1) & 0x3),
+            en=(val >> 0) & 0x1,
+        )
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.a.all;
+
+package p is
+   --thdl:gen
+   type t_rec is record
+      status : t_status;
+      mode   : t_mode;
+      en     : std_logic;
+   end record;
+
+   --thdl:start checksum=60a3ff75
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_REC_WIDTH : natural := 7;
+   constant C_REC_STATUS_HI : natural := 6;
+   constant C_REC_STATUS_LO : natural := 3;
+   constant C_REC_MODE_HI : natural := 2;
+   constant C_REC_MODE_LO : natural := 1;
+   constant C_REC_EN_HI : natural := 0;
+   constant C_REC_EN_LO : natural := 0;
+
+   function to_rec(slv : std_logic_vector(6 downto 0)) return t_rec;
+   function to_slv(rec : t_rec) return std_logic_vector;
+   function to_str(rec : t_rec; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=c7eb829a
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_rec(slv : std_logic_vector(6 downto 0)) return t_rec is
+      variable rec : t_rec;
+   begin
+      rec.status := to_status(slv(6 downto 3));
+      rec.mode := to_mode(slv(2 downto 1));
+      rec.en := slv(0);
+      return rec;
+   end function;
+
+   function to_slv(rec : t_rec) return std_logic_vector is
+      variable slv : std_logic_vector(6 downto 0);
+   begin
+      slv(6 downto 3) := to_slv(rec.status);
+      slv(2 downto 1) := to_slv(rec.mode);
+      slv(0) := rec.en;
+      return slv;
+   end function;
+
+   function to_str(rec : t_rec; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "status => " & to_str(rec.status) & ", " & "mode => " & to_str(rec.mode) & ", " & "en => " & to_string(rec.en) & ")";
+      end if;
+      return "(" & to_str(rec.status) & ", " & to_str(rec.mode) & ", " & to_string(rec.en) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
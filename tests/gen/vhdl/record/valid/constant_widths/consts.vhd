@@ -0,0 +1,8 @@
+package consts is
+   generic (
+      ADDR_W : natural := 12;
+      ID_W   : positive := 4
+   );
+
+   constant BYTES : natural := 4;
+end package;
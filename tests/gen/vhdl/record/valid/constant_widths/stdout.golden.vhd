@@ -0,0 +1,82 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   constant DATA_W : natural := BYTES * 8;
+   constant CNT_W  : natural := 3;
+
+   --thdl:gen
+   type t_rec is record
+      data  : std_logic_vector(DATA_W - 1 downto 0);
+      addr  : unsigned(consts.ADDR_W - 1 downto 0);
+      id    : std_logic_vector(ID_W-1 downto 0);
+      cnt   : std_logic_vector((2**CNT_W) - 1 downto 0);
+      strb  : std_logic_vector(DATA_W / 8 - 1 downto 0);
+   end record;
+
+   --thdl:start checksum=40f15271
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_REC_WIDTH : natural := 60;
+   constant C_REC_DATA_HI : natural := 59;
+   constant C_REC_DATA_LO : natural := 28;
+   constant C_REC_ADDR_HI : natural := 27;
+   constant C_REC_ADDR_LO : natural := 16;
+   constant C_REC_ID_HI : natural := 15;
+   constant C_REC_ID_LO : natural := 12;
+   constant C_REC_CNT_HI : natural := 11;
+   constant C_REC_CNT_LO : natural := 4;
+   constant C_REC_STRB_HI : natural := 3;
+   constant C_REC_STRB_LO : natural := 0;
+
+   function to_rec(slv : std_logic_vector(59 downto 0)) return t_rec;
+   function to_slv(rec : t_rec) return std_logic_vector;
+   function to_str(rec : t_rec; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=c84a2398
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_rec(slv : std_logic_vector(59 downto 0)) return t_rec is
+      variable rec : t_rec;
+   begin
+      rec.data := slv(59 downto 28);
+      rec.addr := unsigned(slv(27 downto 16));
+      rec.id := slv(15 downto 12);
+      rec.cnt := slv(11 downto 4);
+      rec.strb := slv(3 downto 0);
+      return rec;
+   end function;
+
+   function to_slv(rec : t_rec) return std_logic_vector is
+      variable slv : std_logic_vector(59 downto 0);
+   begin
+      slv(59 downto 28) := rec.data;
+      slv(27 downto 16) := std_logic_vector(rec.addr);
+      slv(15 downto 12) := rec.id;
+      slv(11 downto 4) := rec.cnt;
+      slv(3 downto 0) := rec.strb;
+      return slv;
+   end function;
+
+   function to_str(rec : t_rec; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "data => " & to_string(rec.data) & ", " & "addr => " & to_string(rec.addr) & ", " & "id => " & to_string(rec.id) & ", " & "cnt => " & to_string(rec.cnt) & ", " & "strb => " & to_string(rec.strb) & ")";
+      end if;
+      return "(" & to_string(rec.data) & ", " & to_string(rec.addr) & ", " & to_string(rec.id) & ", " & to_string(rec.cnt) & ", " & to_string(rec.strb) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,20 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   constant DATA_W : natural := BYTES * 8;
+   constant CNT_W  : natural := 3;
+
+   --thdl:gen
+   type t_rec is record
+      data  : std_logic_vector(DATA_W - 1 downto 0);
+      addr  : unsigned(consts.ADDR_W - 1 downto 0);
+      id    : std_logic_vector(ID_W-1 downto 0);
+      cnt   : std_logic_vector((2**CNT_W) - 1 downto 0);
+      strb  : std_logic_vector(DATA_W / 8 - 1 downto 0);
+   end record;
+end package;
+
+package body p is
+end package body;
@@ -0,0 +1,68 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen
+   type t_rec is record
+      a : t_foreign; --thdl: width=3 to-type=slv_to_foreign to-slv=foreign_to_slv
+      b : std_ulogic_vector(1 downto 0);
+      c : t_other; --thdl: width=2 to-str=other_to_str
+   end record;
+
+   --thdl:start checksum=15a5b267
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_REC_WIDTH : natural := 7;
+   constant C_REC_A_HI : natural := 6;
+   constant C_REC_A_LO : natural := 4;
+   constant C_REC_B_HI : natural := 3;
+   constant C_REC_B_LO : natural := 2;
+   constant C_REC_C_HI : natural := 1;
+   constant C_REC_C_LO : natural := 0;
+
+   function to_rec(slv : std_logic_vector(6 downto 0)) return t_rec;
+   function to_slv(rec : t_rec) return std_logic_vector;
+   function to_str(rec : t_rec; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=6099e311
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_rec(slv : std_logic_vector(6 downto 0)) return t_rec is
+      variable rec : t_rec;
+   begin
+      rec.a := slv_to_foreign(slv(6 downto 4));
+      rec.b := slv(3 downto 2);
+      rec.c := to_other(slv(1 downto 0));
+      return rec;
+   end function;
+
+   function to_slv(rec : t_rec) return std_logic_vector is
+      variable slv : std_logic_vector(6 downto 0);
+   begin
+      slv(6 downto 4) := foreign_to_slv(rec.a);
+      slv(3 downto 2) := rec.b;
+      slv(1 downto 0) := to_slv(rec.c);
+      return slv;
+   end function;
+
+   function to_str(rec : t_rec; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "a => " & to_str(rec.a) & ", " & "b => " & to_string(rec.b) & ", " & "c => " & other_to_str(rec.c) & ")";
+      end if;
+      return "(" & to_str(rec.a) & ", " & to_string(rec.b) & ", " & other_to_str(rec.c) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,14 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package p is
+   --thdl:gen
+   type t_rec is record
+      a : t_foreign; --thdl: width=3 to-type=slv_to_foreign to-slv=foreign_to_slv
+      b : std_ulogic_vector(1 downto 0);
+      c : t_other; --thdl: width=2 to-str=other_to_str
+   end record;
+end package;
+
+package body p is
+end package body;
@@ -0,0 +1,228 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen encoding=one-hot
+   type t_kind is (DATA, CTRL);
+
+   --thdl:gen init
+   type t_hdr is record
+      kind : t_kind;
+      len  : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 1) of std_logic_vector(7 downto 0);
+
+   --thdl:gen masked equal
+   type t_beat is record
+      hdr   : t_hdr;
+      data  : t_bytes;
+      last  : boolean;
+      cnt   : positive;
+      ext   : t_ext; --thdl: width=4
+      valid : std_logic;
+   end record;
+
+   --thdl:start checksum=cfc7d559
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(1 downto 0)) return t_kind;
+   function to_slv(kind : t_kind) return std_logic_vector;
+   function to_str(kind : t_kind) return string;
+
+   constant C_HDR_WIDTH : natural := 10;
+   constant C_HDR_KIND_HI : natural := 9;
+   constant C_HDR_KIND_LO : natural := 8;
+   constant C_HDR_LEN_HI : natural := 7;
+   constant C_HDR_LEN_LO : natural := 0;
+
+   constant C_HDR_INIT : t_hdr := (kind => t_kind'left, len => (others => '0'));
+
+   function to_hdr(slv : std_logic_vector(9 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+
+   function to_bytes(slv : std_logic_vector(15 downto 0)) return t_bytes;
+   function to_slv(bytes : t_bytes) return std_logic_vector;
+   function to_str(bytes : t_bytes) return string;
+
+   constant C_BEAT_WIDTH : natural := 64;
+   constant C_BEAT_HDR_HI : natural := 63;
+   constant C_BEAT_HDR_LO : natural := 54;
+   constant C_BEAT_DATA_HI : natural := 53;
+   constant C_BEAT_DATA_LO : natural := 38;
+   constant C_BEAT_LAST_HI : natural := 37;
+   constant C_BEAT_LAST_LO : natural := 37;
+   constant C_BEAT_CNT_HI : natural := 36;
+   constant C_BEAT_CNT_LO : natural := 5;
+   constant C_BEAT_EXT_HI : natural := 4;
+   constant C_BEAT_EXT_LO : natural := 1;
+   constant C_BEAT_VALID_HI : natural := 0;
+   constant C_BEAT_VALID_LO : natural := 0;
+
+   constant C_BEAT_INIT : t_beat := (hdr => (kind => t_kind'left, len => (others => '0')), data => (others => (others => '0')), last => false, cnt => 1, ext => to_ext((3 downto 0 => '0')), valid => '0');
+
+   type t_beat_mask is record
+      hdr : boolean;
+      data : boolean;
+      last : boolean;
+      cnt : boolean;
+      ext : boolean;
+      valid : boolean;
+   end record;
+
+   function to_beat(slv : std_logic_vector(63 downto 0)) return t_beat;
+   function to_slv(beat : t_beat) return std_logic_vector;
+   function to_str(beat : t_beat; add_names : boolean := false) return string;
+   function to_beat_masked(beat : t_beat; mask : t_beat_mask) return t_beat;
+   function equal(a, b : t_beat; mask : t_beat_mask) return boolean;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=1ab7ea15
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(1 downto 0)) return t_kind is
+   begin
+      case slv is
+         when "01" => return DATA;
+         when "10" => return CTRL;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(kind : t_kind) return std_logic_vector is
+   begin
+      case kind is
+         when DATA => return "01";
+         when CTRL => return "10";
+      end case;
+   end function;
+
+   function to_str(kind : t_kind) return string is
+   begin
+      case kind is
+         when DATA => return "DATA";
+         when CTRL => return "CTRL";
+      end case;
+   end function;
+
+   function to_hdr(slv : std_logic_vector(9 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.kind := to_kind(slv(9 downto 8));
+      hdr.len := unsigned(slv(7 downto 0));
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(9 downto 0);
+   begin
+      slv(9 downto 8) := to_slv(hdr.kind);
+      slv(7 downto 0) := std_logic_vector(hdr.len);
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "kind => " & to_str(hdr.kind) & ", " & "len => " & to_string(hdr.len) & ")";
+      end if;
+      return "(" & to_str(hdr.kind) & ", " & to_string(hdr.len) & ")";
+   end function;
+
+   function to_bytes(slv : std_logic_vector(15 downto 0)) return t_bytes is
+      variable bytes : t_bytes;
+   begin
+      bytes(0) := slv(15 downto 8);
+      bytes(1) := slv(7 downto 0);
+      return bytes;
+   end function;
+
+   function to_slv(bytes : t_bytes) return std_logic_vector is
+      variable slv : std_logic_vector(15 downto 0);
+   begin
+      slv(15 downto 8) := bytes(0);
+      slv(7 downto 0) := bytes(1);
+      return slv;
+   end function;
+
+   function to_str(bytes : t_bytes) return string is
+   begin
+      return "(" & to_string(bytes(0)) & ", " & to_string(bytes(1)) & ")";
+   end function;
+
+   function to_beat(slv : std_logic_vector(63 downto 0)) return t_beat is
+      variable beat : t_beat;
+   begin
+      beat.hdr := to_hdr(slv(63 downto 54));
+      beat.data := to_bytes(slv(53 downto 38));
+      if slv(37) = '1' then
+         beat.last := true;
+      elsif slv(37) = '0' then
+         beat.last := false;
+      else
+         report "bit 37: cannot convert " & to_string(slv(37)) & " to boolean type" severity failure;
+      end if;
+      beat.cnt := to_integer(unsigned(slv(36 downto 5)));
+      beat.ext := to_ext(slv(4 downto 1));
+      beat.valid := slv(0);
+      return beat;
+   end function;
+
+   function to_slv(beat : t_beat) return std_logic_vector is
+      variable slv : std_logic_vector(63 downto 0);
+   begin
+      slv(63 downto 54) := to_slv(beat.hdr);
+      slv(53 downto 38) := to_slv(beat.data);
+      if beat.last then slv(37) := '1'; else slv(37) := '0'; end if;
+      slv(36 downto 5) := std_logic_vector(to_unsigned(beat.cnt, 32));
+      slv(4 downto 1) := to_slv(beat.ext);
+      slv(0) := beat.valid;
+      return slv;
+   end function;
+
+   function to_str(beat : t_beat; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "hdr => " & to_str(beat.hdr) & ", " & "data => " & to_str(beat.data) & ", " & "last => " & to_string(beat.last) & ", " & "cnt => " & to_string(beat.cnt) & ", " & "ext => " & to_str(beat.ext) & ", " & "valid => " & to_string(beat.valid) & ")";
+      end if;
+      return "(" & to_str(beat.hdr) & ", " & to_str(beat.data) & ", " & to_string(beat.last) & ", " & to_string(beat.cnt) & ", " & to_str(beat.ext) & ", " & to_string(beat.valid) & ")";
+   end function;
+
+   function to_beat_masked(beat : t_beat; mask : t_beat_mask) return t_beat is
+      variable masked : t_beat := C_BEAT_INIT;
+   begin
+      if mask.hdr then masked.hdr := beat.hdr; end if;
+      if mask.data then masked.data := beat.data; end if;
+      if mask.last then masked.last := beat.last; end if;
+      if mask.cnt then masked.cnt := beat.cnt; end if;
+      if mask.ext then masked.ext := beat.ext; end if;
+      if mask.valid then masked.valid := beat.valid; end if;
+      return masked;
+   end function;
+
+   function equal(a, b : t_beat; mask : t_beat_mask) return boolean is
+   begin
+      if mask.hdr and a.hdr /= b.hdr then return false; end if;
+      if mask.data and a.data /= b.data then return false; end if;
+      if mask.last and a.last /= b.last then return false; end if;
+      if mask.cnt and a.cnt /= b.cnt then return false; end if;
+      if mask.ext and a.ext /= b.ext then return false; end if;
+      if mask.valid and a.valid /= b.valid then return false; end if;
+      return true;
+   end function;
+
+   --thdl:end
+
+end package body;
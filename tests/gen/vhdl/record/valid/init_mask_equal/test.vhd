@@ -0,0 +1,30 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen encoding=one-hot
+   type t_kind is (DATA, CTRL);
+
+   --thdl:gen init
+   type t_hdr is record
+      kind : t_kind;
+      len  : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 1) of std_logic_vector(7 downto 0);
+
+   --thdl:gen masked equal
+   type t_beat is record
+      hdr   : t_hdr;
+      data  : t_bytes;
+      last  : boolean;
+      cnt   : positive;
+      ext   : t_ext; --thdl: width=4
+      valid : std_logic;
+   end record;
+end package;
+
+package body p is
+end package body;
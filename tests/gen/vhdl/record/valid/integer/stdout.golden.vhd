@@ -10,11 +10,19 @@ package p is
       p : positive;
    end record;
 
-   --thdl:start
+   --thdl:start checksum=f2c075cc
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
 
+   constant C_REC_WIDTH : natural := 96;
+   constant C_REC_I_HI : natural := 95;
+   constant C_REC_I_LO : natural := 64;
+   constant C_REC_N_HI : natural := 63;
+   constant C_REC_N_LO : natural := 32;
+   constant C_REC_P_HI : natural := 31;
+   constant C_REC_P_LO : natural := 0;
+
    function to_rec(slv : std_logic_vector(95 downto 0)) return t_rec;
    function to_slv(rec : t_rec) return std_logic_vector;
    function to_str(rec : t_rec; add_names : boolean := false) return string;
@@ -25,7 +33,7 @@ end package;
 
 package body p is
 
-   --thdl:start
+   --thdl:start checksum=60e7cc1a
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
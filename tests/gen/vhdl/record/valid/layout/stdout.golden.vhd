@@ -0,0 +1,114 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      mode  : std_logic_vector(1 downto 0); --thdl: pad=5
+      len   : unsigned(7 downto 0);
+      irq   : std_logic; --thdl: offset=24
+   end record;
+
+   --thdl:gen align=16
+   type t_hdr is record
+      ver  : std_logic_vector(3 downto 0); --thdl: pad=4
+      kind : std_logic_vector(3 downto 0);
+   end record;
+
+   --thdl:start checksum=f5f45c9b
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_CTRL_WIDTH : natural := 32;
+   constant C_CTRL_EN_HI : natural := 0;
+   constant C_CTRL_EN_LO : natural := 0;
+   constant C_CTRL_MODE_HI : natural := 2;
+   constant C_CTRL_MODE_LO : natural := 1;
+   constant C_CTRL_LEN_HI : natural := 15;
+   constant C_CTRL_LEN_LO : natural := 8;
+   constant C_CTRL_IRQ_HI : natural := 24;
+   constant C_CTRL_IRQ_LO : natural := 24;
+
+   function to_ctrl(slv : std_logic_vector(31 downto 0)) return t_ctrl;
+   function to_slv(ctrl : t_ctrl) return std_logic_vector;
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string;
+
+   constant C_HDR_WIDTH : natural := 16;
+   constant C_HDR_VER_HI : natural := 11;
+   constant C_HDR_VER_LO : natural := 8;
+   constant C_HDR_KIND_HI : natural := 3;
+   constant C_HDR_KIND_LO : natural := 0;
+
+   function to_hdr(slv : std_logic_vector(15 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=f4da213a
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_ctrl(slv : std_logic_vector(31 downto 0)) return t_ctrl is
+      variable ctrl : t_ctrl;
+   begin
+      ctrl.en := slv(0);
+      ctrl.mode := slv(2 downto 1);
+      ctrl.len := unsigned(slv(15 downto 8));
+      ctrl.irq := slv(24);
+      return ctrl;
+   end function;
+
+   function to_slv(ctrl : t_ctrl) return std_logic_vector is
+      variable slv : std_logic_vector(31 downto 0) := (others => '0');
+   begin
+      slv(0) := ctrl.en;
+      slv(2 downto 1) := ctrl.mode;
+      slv(15 downto 8) := std_logic_vector(ctrl.len);
+      slv(24) := ctrl.irq;
+      return slv;
+   end function;
+
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "en => " & to_string(ctrl.en) & ", " & "mode => " & to_string(ctrl.mode) & ", " & "len => " & to_string(ctrl.len) & ", " & "irq => " & to_string(ctrl.irq) & ")";
+      end if;
+      return "(" & to_string(ctrl.en) & ", " & to_string(ctrl.mode) & ", " & to_string(ctrl.len) & ", " & to_string(ctrl.irq) & ")";
+   end function;
+
+   function to_hdr(slv : std_logic_vector(15 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.ver := slv(11 downto 8);
+      hdr.kind := slv(3 downto 0);
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(15 downto 0) := (others => '0');
+   begin
+      slv(11 downto 8) := hdr.ver;
+      slv(3 downto 0) := hdr.kind;
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "ver => " & to_string(hdr.ver) & ", " & "kind => " & to_string(hdr.kind) & ")";
+      end if;
+      return "(" & to_string(hdr.ver) & ", " & to_string(hdr.kind) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
@@ -0,0 +1,22 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      mode  : std_logic_vector(1 downto 0); --thdl: pad=5
+      len   : unsigned(7 downto 0);
+      irq   : std_logic; --thdl: offset=24
+   end record;
+
+   --thdl:gen align=16
+   type t_hdr is record
+      ver  : std_logic_vector(3 downto 0); --thdl: pad=4
+      kind : std_logic_vector(3 downto 0);
+   end record;
+end package;
+
+package body p is
+end package body;
@@ -0,0 +1,148 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen serialize=bytes
+   type t_hdr is record
+      typ  : std_logic_vector(3 downto 0);
+      len  : unsigned(11 downto 0);
+      last : std_logic;
+   end record;
+
+   --thdl:gen serialize=words endian=little no-to-str
+   type t_desc is record
+      addr : std_logic_vector(31 downto 0);
+      size : unsigned(15 downto 0);
+   end record;
+
+   --thdl:start checksum=adedb0a7
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_HDR_WIDTH : natural := 17;
+   constant C_HDR_TYP_HI : natural := 16;
+   constant C_HDR_TYP_LO : natural := 13;
+   constant C_HDR_LEN_HI : natural := 12;
+   constant C_HDR_LEN_LO : natural := 1;
+   constant C_HDR_LAST_HI : natural := 0;
+   constant C_HDR_LAST_LO : natural := 0;
+
+   type t_hdr_bytes is array (0 to 2) of std_logic_vector(7 downto 0);
+
+   function to_hdr(slv : std_logic_vector(16 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+   function to_bytes(hdr : t_hdr) return t_hdr_bytes;
+   function from_bytes(bytes : t_hdr_bytes) return t_hdr;
+
+   constant C_DESC_WIDTH : natural := 48;
+   constant C_DESC_ADDR_HI : natural := 47;
+   constant C_DESC_ADDR_LO : natural := 16;
+   constant C_DESC_SIZE_HI : natural := 15;
+   constant C_DESC_SIZE_LO : natural := 0;
+
+   type t_desc_words is array (0 to 1) of std_logic_vector(31 downto 0);
+
+   function to_desc(slv : std_logic_vector(47 downto 0)) return t_desc;
+   function to_slv(desc : t_desc) return std_logic_vector;
+   function to_words(desc : t_desc) return t_desc_words;
+   function from_words(words : t_desc_words) return t_desc;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=33a65e59
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_hdr(slv : std_logic_vector(16 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.typ := slv(16 downto 13);
+      hdr.len := unsigned(slv(12 downto 1));
+      hdr.last := slv(0);
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(16 downto 0);
+   begin
+      slv(16 downto 13) := hdr.typ;
+      slv(12 downto 1) := std_logic_vector(hdr.len);
+      slv(0) := hdr.last;
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "typ => " & to_string(hdr.typ) & ", " & "len => " & to_string(hdr.len) & ", " & "last => " & to_string(hdr.last) & ")";
+      end if;
+      return "(" & to_string(hdr.typ) & ", " & to_string(hdr.len) & ", " & to_string(hdr.last) & ")";
+   end function;
+
+   function to_bytes(hdr : t_hdr) return t_hdr_bytes is
+      variable slv : std_logic_vector(23 downto 0) := (others => '0');
+      variable bytes : t_hdr_bytes;
+   begin
+      slv(16 downto 0) := to_slv(hdr);
+      for i in bytes'range loop
+         bytes(i) := slv(8 * (2 - i) + 7 downto 8 * (2 - i));
+      end loop;
+      return bytes;
+   end function;
+
+   function from_bytes(bytes : t_hdr_bytes) return t_hdr is
+      variable slv : std_logic_vector(23 downto 0);
+   begin
+      for i in bytes'range loop
+         slv(8 * (2 - i) + 7 downto 8 * (2 - i)) := bytes(i);
+      end loop;
+      return to_hdr(slv(16 downto 0));
+   end function;
+
+   function to_desc(slv : std_logic_vector(47 downto 0)) return t_desc is
+      variable desc : t_desc;
+   begin
+      desc.addr := slv(47 downto 16);
+      desc.size := unsigned(slv(15 downto 0));
+      return desc;
+   end function;
+
+   function to_slv(desc : t_desc) return std_logic_vector is
+      variable slv : std_logic_vector(47 downto 0);
+   begin
+      slv(47 downto 16) := desc.addr;
+      slv(15 downto 0) := std_logic_vector(desc.size);
+      return slv;
+   end function;
+
+   function to_words(desc : t_desc) return t_desc_words is
+      variable slv : std_logic_vector(63 downto 0) := (others => '0');
+      variable words : t_desc_words;
+   begin
+      slv(47 downto 0) := to_slv(desc);
+      for i in words'range loop
+         words(i) := slv(32 * i + 31 downto 32 * i);
+      end loop;
+      return words;
+   end function;
+
+   function from_words(words : t_desc_words) return t_desc is
+      variable slv : std_logic_vector(63 downto 0);
+   begin
+      for i in words'range loop
+         slv(32 * i + 31 downto 32 * i) := words(i);
+      end loop;
+      return to_desc(slv(47 downto 0));
+   end function;
+
+   --thdl:end
+
+end package body;
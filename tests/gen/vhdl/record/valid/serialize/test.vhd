@@ -0,0 +1,21 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package p is
+   --thdl:gen serialize=bytes
+   type t_hdr is record
+      typ  : std_logic_vector(3 downto 0);
+      len  : unsigned(11 downto 0);
+      last : std_logic;
+   end record;
+
+   --thdl:gen serialize=words endian=little no-to-str
+   type t_desc is record
+      addr : std_logic_vector(31 downto 0);
+      size : unsigned(15 downto 0);
+   end record;
+end package;
+
+package body p is
+end package body;
@@ -10,11 +10,21 @@ package p is
       su : std_ulogic;
    end record;
 
-   --thdl:start
+   --thdl:start checksum=b80d0523
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
 
+   constant C_REC_WIDTH : natural := 4;
+   constant C_REC_BI_HI : natural := 3;
+   constant C_REC_BI_LO : natural := 3;
+   constant C_REC_BO_HI : natural := 2;
+   constant C_REC_BO_LO : natural := 2;
+   constant C_REC_SL_HI : natural := 1;
+   constant C_REC_SL_LO : natural := 1;
+   constant C_REC_SU_HI : natural := 0;
+   constant C_REC_SU_LO : natural := 0;
+
    function to_rec(slv : std_logic_vector(3 downto 0)) return t_rec;
    function to_slv(rec : t_rec) return std_logic_vector;
    function to_str(rec : t_rec; add_names : boolean := false) return string;
@@ -25,7 +35,7 @@ end package;
 
 package body p is
 
-   --thdl:start
+   --thdl:start checksum=c7abcf4a
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
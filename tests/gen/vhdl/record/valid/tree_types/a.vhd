@@ -0,0 +1,4 @@
+package a is
+   --thdl:gen encoding=one-hot
+   type t_status is (OK, ERR, BUSY);
+end package;
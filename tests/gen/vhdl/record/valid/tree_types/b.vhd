@@ -0,0 +1,4 @@
+package b is
+   --thdl:gen
+   type t_status is (OK, ERR, BUSY);
+end package;
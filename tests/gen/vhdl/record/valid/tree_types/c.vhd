@@ -0,0 +1,13 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+package c is
+   --thdl:gen
+   type t_mode is (READ, WRITE, IDLE);
+
+   --thdl:gen
+   type t_cmd is record
+      mode : t_mode;
+      last : std_logic;
+   end record;
+end package;
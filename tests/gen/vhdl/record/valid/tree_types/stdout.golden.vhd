@@ -0,0 +1,72 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.a.all;
+   use work.c.all;
+
+package p is
+   --thdl:gen
+   type t_rec is record
+      status : t_status;
+      mode   : t_mode;
+      cmd    : t_cmd;
+   end record;
+
+   --thdl:start checksum=23810d03
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_REC_WIDTH : natural := 8;
+   constant C_REC_STATUS_HI : natural := 7;
+   constant C_REC_STATUS_LO : natural := 5;
+   constant C_REC_MODE_HI : natural := 4;
+   constant C_REC_MODE_LO : natural := 3;
+   constant C_REC_CMD_HI : natural := 2;
+   constant C_REC_CMD_LO : natural := 0;
+
+   function to_rec(slv : std_logic_vector(7 downto 0)) return t_rec;
+   function to_slv(rec : t_rec) return std_logic_vector;
+   function to_str(rec : t_rec; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body p is
+
+   --thdl:start checksum=a8d2f5d0
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_rec(slv : std_logic_vector(7 downto 0)) return t_rec is
+      variable rec : t_rec;
+   begin
+      rec.status := to_status(slv(7 downto 5));
+      rec.mode := to_mode(slv(4 downto 3));
+      rec.cmd := to_cmd(slv(2 downto 0));
+      return rec;
+   end function;
+
+   function to_slv(rec : t_rec) return std_logic_vector is
+      variable slv : std_logic_vector(7 downto 0);
+   begin
+      slv(7 downto 5) := to_slv(rec.status);
+      slv(4 downto 3) := to_slv(rec.mode);
+      slv(2 downto 0) := to_slv(rec.cmd);
+      return slv;
+   end function;
+
+   function to_str(rec : t_rec; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "status => " & to_str(rec.status) & ", " & "mode => " & to_str(rec.mode) & ", " & "cmd => " & to_str(rec.cmd) & ")";
+      end if;
+      return "(" & to_str(rec.status) & ", " & to_str(rec.mode) & ", " & to_str(rec.cmd) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
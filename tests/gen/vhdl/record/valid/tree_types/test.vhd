@@ -0,0 +1,18 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.a.all;
+   use work.c.all;
+
+package p is
+   --thdl:gen
+   type t_rec is record
+      status : t_status;
+      mode   : t_mode;
+      cmd    : t_cmd;
+   end record;
+end package;
+
+package body p is
+end package body;
@@ -12,15 +12,23 @@ package p is
       f : t_field; --thdl: width=4 to-type=lorem to-slv=ipsum to-str=dolor
    end record;
 
-   --thdl:start
+   --thdl:start checksum=40ebedad
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
 
+   constant C_FOO_WIDTH : natural := 7;
+   constant C_FOO_F_HI : natural := 6;
+   constant C_FOO_F_LO : natural := 0;
+
    function to_foo(slv : std_logic_vector(6 downto 0)) return t_foo;
    function to_slv(foo : t_foo) return std_logic_vector;
    function to_str(foo : t_foo; add_names : boolean := false) return string;
 
+   constant C_BAR_WIDTH : natural := 4;
+   constant C_BAR_F_HI : natural := 3;
+   constant C_BAR_F_LO : natural := 0;
+
    function to_bar(slv : std_logic_vector(3 downto 0)) return t_bar;
    function to_slv(bar : t_bar) return std_logic_vector;
    function to_str(bar : t_bar; add_names : boolean := false) return string;
@@ -31,7 +39,7 @@ end package;
 
 package body p is
 
-   --thdl:start
+   --thdl:start checksum=626c8f68
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -53,9 +61,9 @@ package body p is
    function to_str(foo : t_foo; add_names : boolean := false) return string is
    begin
       if add_names then
-         return "(" & "f => " & (foo.f) & ")";
+         return "(" & "f => " & to_str(foo.f) & ")";
       end if;
-      return "(" & (foo.f) & ")";
+      return "(" & to_str(foo.f) & ")";
    end function;
 
    function to_bar(slv : std_logic_vector(3 downto 0)) return t_bar is
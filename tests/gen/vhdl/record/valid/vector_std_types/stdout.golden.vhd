@@ -10,11 +10,21 @@ package p is
       su  : unsigned(3 downto 0);
    end record;
 
-   --thdl:start
+   --thdl:start checksum=b1a84551
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
 
+   constant C_REC_WIDTH : natural := 10;
+   constant C_REC_SLV_HI : natural := 9;
+   constant C_REC_SLV_LO : natural := 9;
+   constant C_REC_SUV_HI : natural := 8;
+   constant C_REC_SUV_LO : natural := 7;
+   constant C_REC_SI_HI : natural := 6;
+   constant C_REC_SI_LO : natural := 4;
+   constant C_REC_SU_HI : natural := 3;
+   constant C_REC_SU_LO : natural := 0;
+
    function to_rec(slv : std_logic_vector(9 downto 0)) return t_rec;
    function to_slv(rec : t_rec) return std_logic_vector;
    function to_str(rec : t_rec; add_names : boolean := false) return string;
@@ -25,7 +35,7 @@ end package;
 
 package body p is
 
-   --thdl:start
+   --thdl:start checksum=db3fda61
    -- Below code was automatically generated with the thdl tool.
    -- Do not modify it by hand, unless you really know what you do.
    -- More info on https://github.com/m-kru/go-thdl.
@@ -53,9 +63,9 @@ package body p is
    function to_str(rec : t_rec; add_names : boolean := false) return string is
    begin
       if add_names then
-         return "(" & "slv => " & to_string(rec.slv) & ", " & "suv => " & (rec.suv) & ", " & "si => " & to_string(rec.si) & ", " & "su => " & to_string(rec.su) & ")";
+         return "(" & "slv => " & to_string(rec.slv) & ", " & "suv => " & to_string(rec.suv) & ", " & "si => " & to_string(rec.si) & ", " & "su => " & to_string(rec.su) & ")";
       end if;
-      return "(" & to_string(rec.slv) & ", " & (rec.suv) & ", " & to_string(rec.si) & ", " & to_string(rec.su) & ")";
+      return "(" & to_string(rec.slv) & ", " & to_string(rec.suv) & ", " & to_string(rec.si) & ", " & to_string(rec.su) & ")";
    end function;
 
    --thdl:end
@@ -0,0 +1,329 @@
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL package 'ctrl_pkg', record type 't_ctrl'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+library work;
+   use work.ctrl_pkg.all;
+
+entity ctrl_regs is
+   port (
+      clk_i     : in  std_logic;
+      rst_i     : in  std_logic;
+      awaddr_i  : in  std_logic_vector(31 downto 0);
+      awvalid_i : in  std_logic;
+      awready_o : out std_logic;
+      wdata_i   : in  std_logic_vector(31 downto 0);
+      wstrb_i   : in  std_logic_vector(3 downto 0);
+      wvalid_i  : in  std_logic;
+      wready_o  : out std_logic;
+      bresp_o   : out std_logic_vector(1 downto 0);
+      bvalid_o  : out std_logic;
+      bready_i  : in  std_logic;
+      araddr_i  : in  std_logic_vector(31 downto 0);
+      arvalid_i : in  std_logic;
+      arready_o : out std_logic;
+      rdata_o   : out std_logic_vector(31 downto 0);
+      rresp_o   : out std_logic_vector(1 downto 0);
+      rvalid_o  : out std_logic;
+      rready_i  : in  std_logic;
+      regs_i    : in  t_ctrl;
+      regs_o    : out t_ctrl
+   );
+end entity;
+
+architecture rtl of ctrl_regs is
+
+   -- Returns data with bytes selected by the strobe replaced with bytes of the wdata.
+   function strobe(data, wdata : std_logic_vector(31 downto 0); strb : std_logic_vector(3 downto 0)) return std_logic_vector is
+      variable res : std_logic_vector(31 downto 0) := data;
+   begin
+      for i in 0 to 3 loop
+         if strb(i) = '1' then
+            res(8 * i + 7 downto 8 * i) := wdata(8 * i + 7 downto 8 * i);
+         end if;
+      end loop;
+      return res;
+   end function;
+
+   signal regs : t_ctrl := C_CTRL_RESET;
+
+   signal awready, wready, bvalid : std_logic := '0';
+   signal awaddr, wdata : std_logic_vector(31 downto 0) := (others => '0');
+   signal wstrb : std_logic_vector(3 downto 0) := (others => '0');
+   signal arready, rvalid : std_logic := '0';
+   signal araddr, rdata : std_logic_vector(31 downto 0) := (others => '0');
+
+begin
+
+   regs_o <= regs;
+
+   awready_o <= awready;
+   wready_o  <= wready;
+   bresp_o   <= "00";
+   bvalid_o  <= bvalid;
+   arready_o <= arready;
+   rdata_o   <= rdata;
+   rresp_o   <= "00";
+   rvalid_o  <= rvalid;
+
+   bus_write : process (clk_i) is
+      variable v    : t_ctrl;
+      variable data : std_logic_vector(31 downto 0);
+   begin
+      if rising_edge(clk_i) then
+         v := regs;
+         awready <= '0';
+         wready  <= '0';
+         if bvalid = '1' and bready_i = '1' then
+            bvalid <= '0';
+         end if;
+
+         if awvalid_i = '1' and wvalid_i = '1' and awready = '0' and bvalid = '0' then
+            awready <= '1';
+            wready  <= '1';
+            awaddr  <= awaddr_i;
+            wdata   <= wdata_i;
+            wstrb   <= wstrb_i;
+         end if;
+
+         -- Write response follows the cycle of the address and data handshake.
+         if awready = '1' and wready = '1' then
+            bvalid <= '1';
+            if awaddr(31 downto 2) = C_CTRL_ENABLE_ADDR(31 downto 2) then
+               data := (others => '0');
+               data(0) := v.enable;
+               data := strobe(data, wdata, wstrb);
+               v.enable := data(0);
+            end if;
+            if awaddr(31 downto 2) = C_CTRL_DIVIDER_ADDR(31 downto 2) then
+               data := (others => '0');
+               data(15 downto 0) := std_logic_vector(v.divider);
+               data := strobe(data, wdata, wstrb);
+               v.divider := unsigned(data(15 downto 0));
+            end if;
+            if awaddr(31 downto 2) = C_CTRL_IRQ_ADDR(31 downto 2) then
+               data := (others => '0');
+               data := strobe(data, wdata, wstrb);
+               v.irq := v.irq and not data(3 downto 0);
+            end if;
+            if awaddr(31 downto 2) = C_CTRL_CMD_ADDR(31 downto 2) then
+               data := (others => '0');
+               data(31 downto 0) := v.cmd;
+               data := strobe(data, wdata, wstrb);
+               v.cmd := data(31 downto 0);
+            end if;
+         end if;
+
+         v.irq := v.irq or regs_i.irq;
+         regs <= v;
+
+         if rst_i = '1' then
+            regs    <= C_CTRL_RESET;
+            awready <= '0';
+            wready  <= '0';
+            bvalid  <= '0';
+         end if;
+      end if;
+   end process;
+
+   bus_read : process (clk_i) is
+      variable data : std_logic_vector(31 downto 0);
+   begin
+      if rising_edge(clk_i) then
+         arready <= '0';
+         if rvalid = '1' and rready_i = '1' then
+            rvalid <= '0';
+         end if;
+
+         if arvalid_i = '1' and arready = '0' and rvalid = '0' then
+            arready <= '1';
+            araddr  <= araddr_i;
+         end if;
+
+         -- Read data follows the cycle of the address handshake.
+         if arready = '1' then
+            rvalid <= '1';
+            data := (others => '0');
+            if araddr(31 downto 2) = C_CTRL_ENABLE_ADDR(31 downto 2) then
+               data(0) := regs.enable;
+            end if;
+            if araddr(31 downto 2) = C_CTRL_DIVIDER_ADDR(31 downto 2) then
+               data(15 downto 0) := std_logic_vector(regs.divider);
+            end if;
+            if araddr(31 downto 2) = C_CTRL_STATUS_ADDR(31 downto 2) then
+               data(7 downto 0) := regs_i.status;
+            end if;
+            if araddr(31 downto 2) = C_CTRL_IRQ_ADDR(31 downto 2) then
+               data(3 downto 0) := regs.irq;
+            end if;
+            rdata <= data;
+         end if;
+
+         if rst_i = '1' then
+            arready <= '0';
+            rvalid  <= '0';
+         end if;
+      end if;
+   end process;
+
+end architecture;
+/*
+ * Below code was automatically generated with the thdl tool.
+ * Do not modify it by hand, unless you really know what you do.
+ * More info on https://github.com/m-kru/go-thdl.
+ *
+ * Source: VHDL package 'ctrl_pkg', record type 't_ctrl'.
+ */
+
+#ifndef CTRL_REGS_H
+#define CTRL_REGS_H
+
+#include <stdint.h>
+
+#define CTRL_REGS_BASE UINT32_C(0x40000000)
+
+/* Register enable, access rw. */
+#define CTRL_REGS_ENABLE_OFFSET 0x00
+#define CTRL_REGS_ENABLE_ADDR UINT32_C(0x40000000)
+#define CTRL_REGS_ENABLE_WIDTH 1
+#define CTRL_REGS_ENABLE_MASK UINT32_C(0x00000001)
+#define CTRL_REGS_ENABLE_RESET UINT32_C(0x00000001)
+
+/* Register divider, access rw. */
+#define CTRL_REGS_DIVIDER_OFFSET 0x04
+#define CTRL_REGS_DIVIDER_ADDR UINT32_C(0x40000004)
+#define CTRL_REGS_DIVIDER_WIDTH 16
+#define CTRL_REGS_DIVIDER_MASK UINT32_C(0x0000ffff)
+#define CTRL_REGS_DIVIDER_RESET UINT32_C(0x000000ff)
+
+/* Register status, access ro. */
+#define CTRL_REGS_STATUS_OFFSET 0x08
+#define CTRL_REGS_STATUS_ADDR UINT32_C(0x40000008)
+#define CTRL_REGS_STATUS_WIDTH 8
+#define CTRL_REGS_STATUS_MASK UINT32_C(0x000000ff)
+#define CTRL_REGS_STATUS_RESET UINT32_C(0x00000000)
+
+/* Register irq, access w1c. */
+#define CTRL_REGS_IRQ_OFFSET 0x10
+#define CTRL_REGS_IRQ_ADDR UINT32_C(0x40000010)
+#define CTRL_REGS_IRQ_WIDTH 4
+#define CTRL_REGS_IRQ_MASK UINT32_C(0x0000000f)
+#define CTRL_REGS_IRQ_RESET UINT32_C(0x00000000)
+
+/* Register cmd, access wo. */
+#define CTRL_REGS_CMD_OFFSET 0x14
+#define CTRL_REGS_CMD_ADDR UINT32_C(0x40000014)
+#define CTRL_REGS_CMD_WIDTH 32
+#define CTRL_REGS_CMD_MASK UINT32_C(0xffffffff)
+#define CTRL_REGS_CMD_RESET UINT32_C(0x00000000)
+
+#endif /* CTRL_REGS_H */
+<!--
+Below code was automatically generated with the thdl tool.
+Do not modify it by hand, unless you really know what you do.
+More info on https://github.com/m-kru/go-thdl.
+-->
+
+# ctrl_regs
+
+Register map of the `t_ctrl` record type declared in the `ctrl_pkg` package.
+
+Bus: AXI4-Lite, base address: 0x40000000.
+
+| Register | Address | Offset | Access | Width | Reset |
+| --- | --- | --- | --- | --- | --- |
+| enable | 0x40000000 | 0x00 | rw | 1 | 0x1 |
+| divider | 0x40000004 | 0x04 | rw | 16 | 0x00ff |
+| status | 0x40000008 | 0x08 | ro | 8 | 0x00 |
+| irq | 0x40000010 | 0x10 | w1c | 4 | 0x0 |
+| cmd | 0x40000014 | 0x14 | wo | 32 | 0x00000000 |
+
+Access types:
+- rw - read-write,
+- ro - read-only, value is driven by the hardware with the regs_i port,
+- wo - write-only, register is read as zero,
+- w1c - write one to clear, bits are set by the hardware with the regs_i port.
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package ctrl_pkg is
+   --thdl:gen regmap bus=axi4lite base=0x40000000 no-to-str
+   type t_ctrl is record
+      enable  : std_logic;                     --thdl: access=rw reset=1
+      divider : unsigned(15 downto 0);         --thdl: access=rw reset=0x00ff
+      status  : std_logic_vector(7 downto 0);  --thdl: access=ro
+      irq     : std_logic_vector(3 downto 0);  --thdl: access=w1c addr=0x10
+      cmd     : std_logic_vector(31 downto 0); --thdl: access=wo
+   end record;
+
+   --thdl:start checksum=0661dcce
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_CTRL_WIDTH : natural := 61;
+   constant C_CTRL_ENABLE_HI : natural := 60;
+   constant C_CTRL_ENABLE_LO : natural := 60;
+   constant C_CTRL_DIVIDER_HI : natural := 59;
+   constant C_CTRL_DIVIDER_LO : natural := 44;
+   constant C_CTRL_STATUS_HI : natural := 43;
+   constant C_CTRL_STATUS_LO : natural := 36;
+   constant C_CTRL_IRQ_HI : natural := 35;
+   constant C_CTRL_IRQ_LO : natural := 32;
+   constant C_CTRL_CMD_HI : natural := 31;
+   constant C_CTRL_CMD_LO : natural := 0;
+
+   constant C_CTRL_BASE : std_logic_vector(31 downto 0) := x"40000000";
+   constant C_CTRL_ENABLE_ADDR : std_logic_vector(31 downto 0) := x"40000000";
+   constant C_CTRL_DIVIDER_ADDR : std_logic_vector(31 downto 0) := x"40000004";
+   constant C_CTRL_STATUS_ADDR : std_logic_vector(31 downto 0) := x"40000008";
+   constant C_CTRL_IRQ_ADDR : std_logic_vector(31 downto 0) := x"40000010";
+   constant C_CTRL_CMD_ADDR : std_logic_vector(31 downto 0) := x"40000014";
+   constant C_CTRL_RESET : t_ctrl := (enable => '1', divider => "0000000011111111", status => "00000000", irq => "0000", cmd => "00000000000000000000000000000000");
+
+   function to_ctrl(slv : std_logic_vector(60 downto 0)) return t_ctrl;
+   function to_slv(ctrl : t_ctrl) return std_logic_vector;
+
+   --thdl:end
+
+end package;
+
+package body ctrl_pkg is
+
+   --thdl:start checksum=09d6976f
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_ctrl(slv : std_logic_vector(60 downto 0)) return t_ctrl is
+      variable ctrl : t_ctrl;
+   begin
+      ctrl.enable := slv(60);
+      ctrl.divider := unsigned(slv(59 downto 44));
+      ctrl.status := slv(43 downto 36);
+      ctrl.irq := slv(35 downto 32);
+      ctrl.cmd := slv(31 downto 0);
+      return ctrl;
+   end function;
+
+   function to_slv(ctrl : t_ctrl) return std_logic_vector is
+      variable slv : std_logic_vector(60 downto 0);
+   begin
+      slv(60) := ctrl.enable;
+      slv(59 downto 44) := std_logic_vector(ctrl.divider);
+      slv(43 downto 36) := ctrl.status;
+      slv(35 downto 32) := ctrl.irq;
+      slv(31 downto 0) := ctrl.cmd;
+      return slv;
+   end function;
+
+   --thdl:end
+
+end package body;
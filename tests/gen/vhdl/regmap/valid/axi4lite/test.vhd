@@ -0,0 +1,17 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package ctrl_pkg is
+   --thdl:gen regmap bus=axi4lite base=0x40000000 no-to-str
+   type t_ctrl is record
+      enable  : std_logic;                     --thdl: access=rw reset=1
+      divider : unsigned(15 downto 0);         --thdl: access=rw reset=0x00ff
+      status  : std_logic_vector(7 downto 0);  --thdl: access=ro
+      irq     : std_logic_vector(3 downto 0);  --thdl: access=w1c addr=0x10
+      cmd     : std_logic_vector(31 downto 0); --thdl: access=wo
+   end record;
+end package;
+
+package body ctrl_pkg is
+end package body;
@@ -0,0 +1,230 @@
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL package 'timer_pkg', record type 't_timer'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+library work;
+   use work.timer_pkg.all;
+
+entity timer_regs is
+   port (
+      clk_i  : in  std_logic;
+      rst_i  : in  std_logic;
+      cyc_i  : in  std_logic;
+      stb_i  : in  std_logic;
+      we_i   : in  std_logic;
+      adr_i  : in  std_logic_vector(31 downto 0);
+      sel_i  : in  std_logic_vector(3 downto 0);
+      dat_i  : in  std_logic_vector(31 downto 0);
+      dat_o  : out std_logic_vector(31 downto 0);
+      ack_o  : out std_logic;
+      regs_i : in  t_timer;
+      regs_o : out t_timer
+   );
+end entity;
+
+architecture rtl of timer_regs is
+
+   -- Returns data with bytes selected by the strobe replaced with bytes of the wdata.
+   function strobe(data, wdata : std_logic_vector(31 downto 0); strb : std_logic_vector(3 downto 0)) return std_logic_vector is
+      variable res : std_logic_vector(31 downto 0) := data;
+   begin
+      for i in 0 to 3 loop
+         if strb(i) = '1' then
+            res(8 * i + 7 downto 8 * i) := wdata(8 * i + 7 downto 8 * i);
+         end if;
+      end loop;
+      return res;
+   end function;
+
+   signal regs : t_timer := C_TIMER_RESET;
+
+   signal ack : std_logic := '0';
+   signal dat : std_logic_vector(31 downto 0) := (others => '0');
+
+begin
+
+   regs_o <= regs;
+
+   ack_o <= ack;
+   dat_o <= dat;
+
+   bus_access : process (clk_i) is
+      variable v    : t_timer;
+      variable data : std_logic_vector(31 downto 0);
+   begin
+      if rising_edge(clk_i) then
+         v := regs;
+         ack <= '0';
+
+         if cyc_i = '1' and stb_i = '1' and ack = '0' then
+            ack <= '1';
+            if we_i = '1' then
+               if adr_i(31 downto 2) = C_TIMER_LOAD_ADDR(31 downto 2) then
+                  data := (others => '0');
+                  data(31 downto 0) := std_logic_vector(v.load);
+                  data := strobe(data, dat_i, sel_i);
+                  v.load := unsigned(data(31 downto 0));
+               end if;
+               if adr_i(31 downto 2) = C_TIMER_FLAGS_ADDR(31 downto 2) then
+                  data := (others => '0');
+                  data := strobe(data, dat_i, sel_i);
+                  v.flags := v.flags and not data(1 downto 0);
+               end if;
+            else
+               data := (others => '0');
+               if adr_i(31 downto 2) = C_TIMER_LOAD_ADDR(31 downto 2) then
+                  data(31 downto 0) := std_logic_vector(regs.load);
+               end if;
+               if adr_i(31 downto 2) = C_TIMER_COUNT_ADDR(31 downto 2) then
+                  data(31 downto 0) := std_logic_vector(regs_i.count);
+               end if;
+               if adr_i(31 downto 2) = C_TIMER_FLAGS_ADDR(31 downto 2) then
+                  data(1 downto 0) := regs.flags;
+               end if;
+               dat <= data;
+            end if;
+         end if;
+
+         v.flags := v.flags or regs_i.flags;
+         regs <= v;
+
+         if rst_i = '1' then
+            regs <= C_TIMER_RESET;
+            ack  <= '0';
+         end if;
+      end if;
+   end process;
+
+end architecture;
+/*
+ * Below code was automatically generated with the thdl tool.
+ * Do not modify it by hand, unless you really know what you do.
+ * More info on https://github.com/m-kru/go-thdl.
+ *
+ * Source: VHDL package 'timer_pkg', record type 't_timer'.
+ */
+
+#ifndef TIMER_REGS_H
+#define TIMER_REGS_H
+
+#include <stdint.h>
+
+#define TIMER_REGS_BASE UINT32_C(0x00000000)
+
+/* Register load, access rw. */
+#define TIMER_REGS_LOAD_OFFSET 0x00
+#define TIMER_REGS_LOAD_ADDR UINT32_C(0x00000000)
+#define TIMER_REGS_LOAD_WIDTH 32
+#define TIMER_REGS_LOAD_MASK UINT32_C(0xffffffff)
+#define TIMER_REGS_LOAD_RESET UINT32_C(0x00000000)
+
+/* Register count, access ro. */
+#define TIMER_REGS_COUNT_OFFSET 0x04
+#define TIMER_REGS_COUNT_ADDR UINT32_C(0x00000004)
+#define TIMER_REGS_COUNT_WIDTH 32
+#define TIMER_REGS_COUNT_MASK UINT32_C(0xffffffff)
+#define TIMER_REGS_COUNT_RESET UINT32_C(0x00000000)
+
+/* Register flags, access w1c. */
+#define TIMER_REGS_FLAGS_OFFSET 0x08
+#define TIMER_REGS_FLAGS_ADDR UINT32_C(0x00000008)
+#define TIMER_REGS_FLAGS_WIDTH 2
+#define TIMER_REGS_FLAGS_MASK UINT32_C(0x00000003)
+#define TIMER_REGS_FLAGS_RESET UINT32_C(0x00000000)
+
+#endif /* TIMER_REGS_H */
+<!--
+Below code was automatically generated with the thdl tool.
+Do not modify it by hand, unless you really know what you do.
+More info on https://github.com/m-kru/go-thdl.
+-->
+
+# timer_regs
+
+Register map of the `t_timer` record type declared in the `timer_pkg` package.
+
+Bus: Wishbone, base address: 0x00000000.
+
+| Register | Address | Offset | Access | Width | Reset |
+| --- | --- | --- | --- | --- | --- |
+| load | 0x00000000 | 0x00 | rw | 32 | 0x00000000 |
+| count | 0x00000004 | 0x04 | ro | 32 | 0x00000000 |
+| flags | 0x00000008 | 0x08 | w1c | 2 | 0x0 |
+
+Access types:
+- rw - read-write,
+- ro - read-only, value is driven by the hardware with the regs_i port,
+- wo - write-only, register is read as zero,
+- w1c - write one to clear, bits are set by the hardware with the regs_i port.
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package timer_pkg is
+   --thdl:gen regmap bus=wishbone no-to-str
+   type t_timer is record
+      load  : unsigned(31 downto 0);
+      count : unsigned(31 downto 0); --thdl: access=ro
+      flags : std_logic_vector(1 downto 0); --thdl: access=w1c
+   end record;
+
+   --thdl:start checksum=afba05cf
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_TIMER_WIDTH : natural := 66;
+   constant C_TIMER_LOAD_HI : natural := 65;
+   constant C_TIMER_LOAD_LO : natural := 34;
+   constant C_TIMER_COUNT_HI : natural := 33;
+   constant C_TIMER_COUNT_LO : natural := 2;
+   constant C_TIMER_FLAGS_HI : natural := 1;
+   constant C_TIMER_FLAGS_LO : natural := 0;
+
+   constant C_TIMER_BASE : std_logic_vector(31 downto 0) := x"00000000";
+   constant C_TIMER_LOAD_ADDR : std_logic_vector(31 downto 0) := x"00000000";
+   constant C_TIMER_COUNT_ADDR : std_logic_vector(31 downto 0) := x"00000004";
+   constant C_TIMER_FLAGS_ADDR : std_logic_vector(31 downto 0) := x"00000008";
+   constant C_TIMER_RESET : t_timer := (load => "00000000000000000000000000000000", count => "00000000000000000000000000000000", flags => "00");
+
+   function to_timer(slv : std_logic_vector(65 downto 0)) return t_timer;
+   function to_slv(timer : t_timer) return std_logic_vector;
+
+   --thdl:end
+
+end package;
+
+package body timer_pkg is
+
+   --thdl:start checksum=d11f5706
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_timer(slv : std_logic_vector(65 downto 0)) return t_timer is
+      variable timer : t_timer;
+   begin
+      timer.load := unsigned(slv(65 downto 34));
+      timer.count := unsigned(slv(33 downto 2));
+      timer.flags := slv(1 downto 0);
+      return timer;
+   end function;
+
+   function to_slv(timer : t_timer) return std_logic_vector is
+      variable slv : std_logic_vector(65 downto 0);
+   begin
+      slv(65 downto 34) := std_logic_vector(timer.load);
+      slv(33 downto 2) := std_logic_vector(timer.count);
+      slv(1 downto 0) := timer.flags;
+      return slv;
+   end function;
+
+   --thdl:end
+
+end package body;
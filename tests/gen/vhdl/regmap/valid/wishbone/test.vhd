@@ -0,0 +1,15 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package timer_pkg is
+   --thdl:gen regmap bus=wishbone no-to-str
+   type t_timer is record
+      load  : unsigned(31 downto 0);
+      count : unsigned(31 downto 0); --thdl: access=ro
+      flags : std_logic_vector(1 downto 0); --thdl: access=w1c
+   end record;
+end package;
+
+package body timer_pkg is
+end package body;
@@ -0,0 +1,267 @@
+// Below code was automatically generated with the thdl tool.
+// Do not modify it by hand, unless you really know what you do.
+// More info on https://github.com/m-kru/go-thdl.
+//
+// Source: VHDL package 'pkt'.
+
+package pkt_sv;
+
+   // Enumeration type t_kind, one-hot encoding.
+   typedef enum logic [2:0] {
+      KIND_DATA = 3'b001,
+      KIND_CTRL = 3'b010,
+      KIND_IDLE = 3'b100
+   } kind_t;
+
+   // Record type t_hdr.
+   localparam int HDR_WIDTH = 44;
+
+   typedef struct packed {
+      kind_t kind;
+      logic last;
+      logic [7:0] len;
+      logic signed [31:0] ofs;
+   } hdr_t;
+
+   // Record type t_ctrl.
+   localparam int CTRL_WIDTH = 32;
+
+   typedef struct packed {
+      logic [6:0] _pad0;
+      logic irq;
+      logic [20:0] _pad1;
+      logic [1:0] mode;
+      logic en;
+   } ctrl_t;
+
+   // Array type t_bytes.
+   localparam int BYTES_WIDTH = 32;
+
+   typedef logic [0:3][7:0] bytes_t;
+
+   // Array type t_kinds.
+   localparam int KINDS_WIDTH = 6;
+
+   typedef kind_t [1:0] kinds_t;
+
+endpackage
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package pkt is
+   --thdl:gen encoding=one-hot
+   type t_kind is (DATA, CTRL, IDLE);
+
+   --thdl:gen
+   type t_hdr is record
+      kind  : t_kind;
+      last  : boolean;
+      len   : unsigned(7 downto 0);
+      ofs   : integer;
+   end record;
+
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      mode  : std_logic_vector(1 downto 0); --thdl: pad=5
+      irq   : std_logic; --thdl: offset=24
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 3) of std_logic_vector(7 downto 0);
+
+   --thdl:gen order=lsb-first
+   type t_kinds is array (0 to 1) of t_kind;
+
+   --thdl:start checksum=0eca377d
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(2 downto 0)) return t_kind;
+   function to_slv(kind : t_kind) return std_logic_vector;
+   function to_str(kind : t_kind) return string;
+
+   constant C_HDR_WIDTH : natural := 44;
+   constant C_HDR_KIND_HI : natural := 43;
+   constant C_HDR_KIND_LO : natural := 41;
+   constant C_HDR_LAST_HI : natural := 40;
+   constant C_HDR_LAST_LO : natural := 40;
+   constant C_HDR_LEN_HI : natural := 39;
+   constant C_HDR_LEN_LO : natural := 32;
+   constant C_HDR_OFS_HI : natural := 31;
+   constant C_HDR_OFS_LO : natural := 0;
+
+   function to_hdr(slv : std_logic_vector(43 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+
+   constant C_CTRL_WIDTH : natural := 32;
+   constant C_CTRL_EN_HI : natural := 0;
+   constant C_CTRL_EN_LO : natural := 0;
+   constant C_CTRL_MODE_HI : natural := 2;
+   constant C_CTRL_MODE_LO : natural := 1;
+   constant C_CTRL_IRQ_HI : natural := 24;
+   constant C_CTRL_IRQ_LO : natural := 24;
+
+   function to_ctrl(slv : std_logic_vector(31 downto 0)) return t_ctrl;
+   function to_slv(ctrl : t_ctrl) return std_logic_vector;
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes;
+   function to_slv(bytes : t_bytes) return std_logic_vector;
+   function to_str(bytes : t_bytes) return string;
+
+   function to_kinds(slv : std_logic_vector(5 downto 0)) return t_kinds;
+   function to_slv(kinds : t_kinds) return std_logic_vector;
+   function to_str(kinds : t_kinds) return string;
+
+   --thdl:end
+
+end package;
+
+package body pkt is
+
+   --thdl:start checksum=a3b13183
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_kind(slv : std_logic_vector(2 downto 0)) return t_kind is
+   begin
+      case slv is
+         when "001" => return DATA;
+         when "010" => return CTRL;
+         when "100" => return IDLE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(kind : t_kind) return std_logic_vector is
+   begin
+      case kind is
+         when DATA => return "001";
+         when CTRL => return "010";
+         when IDLE => return "100";
+      end case;
+   end function;
+
+   function to_str(kind : t_kind) return string is
+   begin
+      case kind is
+         when DATA => return "DATA";
+         when CTRL => return "CTRL";
+         when IDLE => return "IDLE";
+      end case;
+   end function;
+
+   function to_hdr(slv : std_logic_vector(43 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.kind := to_kind(slv(43 downto 41));
+      if slv(40) = '1' then
+         hdr.last := true;
+      elsif slv(40) = '0' then
+         hdr.last := false;
+      else
+         report "bit 40: cannot convert " & to_string(slv(40)) & " to boolean type" severity failure;
+      end if;
+      hdr.len := unsigned(slv(39 downto 32));
+      hdr.ofs := to_integer(signed(slv(31 downto 0)));
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(43 downto 0);
+   begin
+      slv(43 downto 41) := to_slv(hdr.kind);
+      if hdr.last then slv(40) := '1'; else slv(40) := '0'; end if;
+      slv(39 downto 32) := std_logic_vector(hdr.len);
+      slv(31 downto 0) := std_logic_vector(to_signed(hdr.ofs, 32));
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "kind => " & to_str(hdr.kind) & ", " & "last => " & to_string(hdr.last) & ", " & "len => " & to_string(hdr.len) & ", " & "ofs => " & to_string(hdr.ofs) & ")";
+      end if;
+      return "(" & to_str(hdr.kind) & ", " & to_string(hdr.last) & ", " & to_string(hdr.len) & ", " & to_string(hdr.ofs) & ")";
+   end function;
+
+   function to_ctrl(slv : std_logic_vector(31 downto 0)) return t_ctrl is
+      variable ctrl : t_ctrl;
+   begin
+      ctrl.en := slv(0);
+      ctrl.mode := slv(2 downto 1);
+      ctrl.irq := slv(24);
+      return ctrl;
+   end function;
+
+   function to_slv(ctrl : t_ctrl) return std_logic_vector is
+      variable slv : std_logic_vector(31 downto 0) := (others => '0');
+   begin
+      slv(0) := ctrl.en;
+      slv(2 downto 1) := ctrl.mode;
+      slv(24) := ctrl.irq;
+      return slv;
+   end function;
+
+   function to_str(ctrl : t_ctrl; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "en => " & to_string(ctrl.en) & ", " & "mode => " & to_string(ctrl.mode) & ", " & "irq => " & to_string(ctrl.irq) & ")";
+      end if;
+      return "(" & to_string(ctrl.en) & ", " & to_string(ctrl.mode) & ", " & to_string(ctrl.irq) & ")";
+   end function;
+
+   function to_bytes(slv : std_logic_vector(31 downto 0)) return t_bytes is
+      variable bytes : t_bytes;
+   begin
+      bytes(0) := slv(31 downto 24);
+      bytes(1) := slv(23 downto 16);
+      bytes(2) := slv(15 downto 8);
+      bytes(3) := slv(7 downto 0);
+      return bytes;
+   end function;
+
+   function to_slv(bytes : t_bytes) return std_logic_vector is
+      variable slv : std_logic_vector(31 downto 0);
+   begin
+      slv(31 downto 24) := bytes(0);
+      slv(23 downto 16) := bytes(1);
+      slv(15 downto 8) := bytes(2);
+      slv(7 downto 0) := bytes(3);
+      return slv;
+   end function;
+
+   function to_str(bytes : t_bytes) return string is
+   begin
+      return "(" & to_string(bytes(0)) & ", " & to_string(bytes(1)) & ", " & to_string(bytes(2)) & ", " & to_string(bytes(3)) & ")";
+   end function;
+
+   function to_kinds(slv : std_logic_vector(5 downto 0)) return t_kinds is
+      variable kinds : t_kinds;
+   begin
+      kinds(1) := to_kind(slv(5 downto 3));
+      kinds(0) := to_kind(slv(2 downto 0));
+      return kinds;
+   end function;
+
+   function to_slv(kinds : t_kinds) return std_logic_vector is
+      variable slv : std_logic_vector(5 downto 0);
+   begin
+      slv(5 downto 3) := to_slv(kinds(1));
+      slv(2 downto 0) := to_slv(kinds(0));
+      return slv;
+   end function;
+
+   function to_str(kinds : t_kinds) return string is
+   begin
+      return "(" & to_str(kinds(0)) & ", " & to_str(kinds(1)) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
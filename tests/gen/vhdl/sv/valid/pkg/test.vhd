@@ -0,0 +1,32 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package pkt is
+   --thdl:gen encoding=one-hot
+   type t_kind is (DATA, CTRL, IDLE);
+
+   --thdl:gen
+   type t_hdr is record
+      kind  : t_kind;
+      last  : boolean;
+      len   : unsigned(7 downto 0);
+      ofs   : integer;
+   end record;
+
+   --thdl:gen order=lsb-first align=32
+   type t_ctrl is record
+      en    : std_logic;
+      mode  : std_logic_vector(1 downto 0); --thdl: pad=5
+      irq   : std_logic; --thdl: offset=24
+   end record;
+
+   --thdl:gen
+   type t_bytes is array (0 to 3) of std_logic_vector(7 downto 0);
+
+   --thdl:gen order=lsb-first
+   type t_kinds is array (0 to 1) of t_kind;
+end package;
+
+package body pkt is
+end package body;
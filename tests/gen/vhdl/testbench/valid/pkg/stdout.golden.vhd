@@ -0,0 +1,379 @@
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL package 'pkg', enumeration type 't_state'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+   use ieee.math_real.all;
+
+library work;
+   use work.pkg.all;
+
+entity tb_state is
+end entity;
+
+architecture test of tb_state is
+begin
+
+   main : process is
+   begin
+      for x in t_state loop
+         assert to_state(to_slv(x)) = x
+            report "to_state(to_slv(x)) round-trip failed for x = " & t_state'image(x)
+            severity failure;
+         assert to_str(x)'length > 0
+            report "to_str(x) returned empty string for x = " & t_state'image(x)
+            severity failure;
+      end loop;
+
+      std.env.finish;
+      wait;
+   end process;
+
+end architecture;
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL package 'pkg', record type 't_hdr'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+   use ieee.math_real.all;
+
+library work;
+   use work.pkg.all;
+   use work.ext_pkg.all;
+
+entity tb_hdr is
+end entity;
+
+architecture test of tb_hdr is
+
+   procedure random(variable s1, s2 : inout positive; variable slv : out std_logic_vector) is
+      variable rand : real;
+   begin
+      for i in slv'range loop
+         uniform(s1, s2, rand);
+         if rand < 0.5 then
+            slv(i) := '0';
+         else
+            slv(i) := '1';
+         end if;
+      end loop;
+   end procedure;
+
+   procedure random(variable s1, s2 : inout positive; variable x : out t_hdr) is
+      variable v : t_hdr;
+      variable state_slv : std_logic_vector(15 downto 0);
+      variable cnt_slv : std_logic_vector(31 downto 0);
+   begin
+      random(s1, s2, state_slv);
+      v.state := t_state'val(to_integer(unsigned(state_slv)) mod (t_state'pos(t_state'right) + 1));
+      random(s1, s2, cnt_slv);
+      v.cnt := to_integer(unsigned(cnt_slv(30 downto 0)));
+      x := v;
+   end procedure;
+
+begin
+
+   main : process is
+      variable s1, s2 : positive := 1;
+      variable x : t_hdr;
+   begin
+      for i in 0 to 999 loop
+         random(s1, s2, x);
+         assert to_hdr(to_slv(x)) = x
+            report "round-trip failed for x = " & to_str(x, true)
+            severity failure;
+         assert to_str(x)'length > 0
+            report "to_str(x) returned empty string in iteration " & integer'image(i)
+            severity failure;
+      end loop;
+
+      std.env.finish;
+      wait;
+   end process;
+
+end architecture;
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL package 'pkg', record type 't_pkt'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+   use ieee.math_real.all;
+
+library work;
+   use work.pkg.all;
+   use work.ext_pkg.all;
+
+entity tb_pkt is
+end entity;
+
+architecture test of tb_pkt is
+
+   procedure random(variable s1, s2 : inout positive; variable slv : out std_logic_vector) is
+      variable rand : real;
+   begin
+      for i in slv'range loop
+         uniform(s1, s2, rand);
+         if rand < 0.5 then
+            slv(i) := '0';
+         else
+            slv(i) := '1';
+         end if;
+      end loop;
+   end procedure;
+
+   procedure random(variable s1, s2 : inout positive; variable x : out t_hdr) is
+      variable v : t_hdr;
+      variable state_slv : std_logic_vector(15 downto 0);
+      variable cnt_slv : std_logic_vector(31 downto 0);
+   begin
+      random(s1, s2, state_slv);
+      v.state := t_state'val(to_integer(unsigned(state_slv)) mod (t_state'pos(t_state'right) + 1));
+      random(s1, s2, cnt_slv);
+      v.cnt := to_integer(unsigned(cnt_slv(30 downto 0)));
+      x := v;
+   end procedure;
+
+   procedure random(variable s1, s2 : inout positive; variable x : out t_states) is
+      variable v : t_states;
+      variable slv : std_logic_vector(15 downto 0);
+   begin
+      for i in v'range loop
+         random(s1, s2, slv);
+         v(i) := t_state'val(to_integer(unsigned(slv)) mod (t_state'pos(t_state'right) + 1));
+      end loop;
+      x := v;
+   end procedure;
+
+   procedure random(variable s1, s2 : inout positive; variable x : out t_pkt) is
+      variable v : t_pkt;
+      variable data_slv : std_logic_vector(11 downto 0);
+      variable last_slv : std_logic_vector(0 downto 0);
+      variable ext_slv : std_logic_vector(4 downto 0);
+   begin
+      random(s1, s2, v.hdr);
+      random(s1, s2, v.states);
+      random(s1, s2, data_slv);
+      v.data := unsigned(data_slv);
+      random(s1, s2, last_slv);
+      v.last := last_slv(0) = '1';
+      random(s1, s2, ext_slv);
+      v.ext := slv_to_ext(ext_slv);
+      x := v;
+   end procedure;
+
+begin
+
+   main : process is
+      variable s1, s2 : positive := 1;
+      variable x : t_pkt;
+   begin
+      for i in 0 to 999 loop
+         random(s1, s2, x);
+         assert to_pkt(to_slv(x)) = x
+            report "round-trip failed in iteration " & integer'image(i)
+            severity failure;
+      end loop;
+
+      std.env.finish;
+      wait;
+   end process;
+
+end architecture;
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+library work;
+   use work.ext_pkg.all;
+
+package pkg is
+   --thdl:gen encoding=one-hot
+   type t_state is (IDLE, RUN, DONE);
+
+   --thdl:gen
+   type t_states is array (0 to 3) of t_state;
+
+   --thdl:gen
+   type t_hdr is record
+      state : t_state;
+      cnt   : natural;
+   end record;
+
+   --thdl:gen no-to-str
+   type t_pkt is record
+      hdr    : t_hdr;
+      states : t_states;
+      data   : unsigned(11 downto 0);
+      last   : boolean;
+      ext    : t_ext; --thdl: width=5 to-type=slv_to_ext
+   end record;
+
+   --thdl:start checksum=9bba368a
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(2 downto 0)) return t_state;
+   function to_slv(state : t_state) return std_logic_vector;
+   function to_str(state : t_state) return string;
+
+   function to_states(slv : std_logic_vector(11 downto 0)) return t_states;
+   function to_slv(states : t_states) return std_logic_vector;
+   function to_str(states : t_states) return string;
+
+   constant C_HDR_WIDTH : natural := 35;
+   constant C_HDR_STATE_HI : natural := 34;
+   constant C_HDR_STATE_LO : natural := 32;
+   constant C_HDR_CNT_HI : natural := 31;
+   constant C_HDR_CNT_LO : natural := 0;
+
+   function to_hdr(slv : std_logic_vector(34 downto 0)) return t_hdr;
+   function to_slv(hdr : t_hdr) return std_logic_vector;
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string;
+
+   constant C_PKT_WIDTH : natural := 65;
+   constant C_PKT_HDR_HI : natural := 64;
+   constant C_PKT_HDR_LO : natural := 30;
+   constant C_PKT_STATES_HI : natural := 29;
+   constant C_PKT_STATES_LO : natural := 18;
+   constant C_PKT_DATA_HI : natural := 17;
+   constant C_PKT_DATA_LO : natural := 6;
+   constant C_PKT_LAST_HI : natural := 5;
+   constant C_PKT_LAST_LO : natural := 5;
+   constant C_PKT_EXT_HI : natural := 4;
+   constant C_PKT_EXT_LO : natural := 0;
+
+   function to_pkt(slv : std_logic_vector(64 downto 0)) return t_pkt;
+   function to_slv(pkt : t_pkt) return std_logic_vector;
+
+   --thdl:end
+
+end package;
+
+package body pkg is
+
+   --thdl:start checksum=1d84557d
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_state(slv : std_logic_vector(2 downto 0)) return t_state is
+   begin
+      case slv is
+         when "001" => return IDLE;
+         when "010" => return RUN;
+         when "100" => return DONE;
+         when others => report "invalid slv value " & to_string(slv) severity failure;
+      end case;
+   end function;
+
+   function to_slv(state : t_state) return std_logic_vector is
+   begin
+      case state is
+         when IDLE => return "001";
+         when RUN => return "010";
+         when DONE => return "100";
+      end case;
+   end function;
+
+   function to_str(state : t_state) return string is
+   begin
+      case state is
+         when IDLE => return "IDLE";
+         when RUN => return "RUN";
+         when DONE => return "DONE";
+      end case;
+   end function;
+
+   function to_states(slv : std_logic_vector(11 downto 0)) return t_states is
+      variable states : t_states;
+   begin
+      states(0) := to_state(slv(11 downto 9));
+      states(1) := to_state(slv(8 downto 6));
+      states(2) := to_state(slv(5 downto 3));
+      states(3) := to_state(slv(2 downto 0));
+      return states;
+   end function;
+
+   function to_slv(states : t_states) return std_logic_vector is
+      variable slv : std_logic_vector(11 downto 0);
+   begin
+      slv(11 downto 9) := to_slv(states(0));
+      slv(8 downto 6) := to_slv(states(1));
+      slv(5 downto 3) := to_slv(states(2));
+      slv(2 downto 0) := to_slv(states(3));
+      return slv;
+   end function;
+
+   function to_str(states : t_states) return string is
+   begin
+      return "(" & to_str(states(0)) & ", " & to_str(states(1)) & ", " & to_str(states(2)) & ", " & to_str(states(3)) & ")";
+   end function;
+
+   function to_hdr(slv : std_logic_vector(34 downto 0)) return t_hdr is
+      variable hdr : t_hdr;
+   begin
+      hdr.state := to_state(slv(34 downto 32));
+      hdr.cnt := to_integer(unsigned(slv(31 downto 0)));
+      return hdr;
+   end function;
+
+   function to_slv(hdr : t_hdr) return std_logic_vector is
+      variable slv : std_logic_vector(34 downto 0);
+   begin
+      slv(34 downto 32) := to_slv(hdr.state);
+      slv(31 downto 0) := std_logic_vector(to_unsigned(hdr.cnt, 32));
+      return slv;
+   end function;
+
+   function to_str(hdr : t_hdr; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "state => " & to_str(hdr.state) & ", " & "cnt => " & to_string(hdr.cnt) & ")";
+      end if;
+      return "(" & to_str(hdr.state) & ", " & to_string(hdr.cnt) & ")";
+   end function;
+
+   function to_pkt(slv : std_logic_vector(64 downto 0)) return t_pkt is
+      variable pkt : t_pkt;
+   begin
+      pkt.hdr := to_hdr(slv(64 downto 30));
+      pkt.states := to_states(slv(29 downto 18));
+      pkt.data := unsigned(slv(17 downto 6));
+      if slv(5) = '1' then
+         pkt.last := true;
+      elsif slv(5) = '0' then
+         pkt.last := false;
+      else
+         report "bit 5: cannot convert " & to_string(slv(5)) & " to boolean type" severity failure;
+      end if;
+      pkt.ext := slv_to_ext(slv(4 downto 0));
+      return pkt;
+   end function;
+
+   function to_slv(pkt : t_pkt) return std_logic_vector is
+      variable slv : std_logic_vector(64 downto 0);
+   begin
+      slv(64 downto 30) := to_slv(pkt.hdr);
+      slv(29 downto 18) := to_slv(pkt.states);
+      slv(17 downto 6) := std_logic_vector(pkt.data);
+      if pkt.last then slv(5) := '1'; else slv(5) := '0'; end if;
+      slv(4 downto 0) := to_slv(pkt.ext);
+      return slv;
+   end function;
+
+   --thdl:end
+
+end package body;
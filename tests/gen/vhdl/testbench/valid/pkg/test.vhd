@@ -0,0 +1,32 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+library work;
+   use work.ext_pkg.all;
+
+package pkg is
+   --thdl:gen encoding=one-hot
+   type t_state is (IDLE, RUN, DONE);
+
+   --thdl:gen
+   type t_states is array (0 to 3) of t_state;
+
+   --thdl:gen
+   type t_hdr is record
+      state : t_state;
+      cnt   : natural;
+   end record;
+
+   --thdl:gen no-to-str
+   type t_pkt is record
+      hdr    : t_hdr;
+      states : t_states;
+      data   : unsigned(11 downto 0);
+      last   : boolean;
+      ext    : t_ext; --thdl: width=5 to-type=slv_to_ext
+   end record;
+end package;
+
+package body pkg is
+end package body;
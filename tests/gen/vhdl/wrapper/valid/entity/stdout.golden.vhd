@@ -0,0 +1,183 @@
+-- Below code was automatically generated with the thdl tool.
+-- Do not modify it by hand, unless you really know what you do.
+-- More info on https://github.com/m-kru/go-thdl.
+--
+-- Source: VHDL entity 'core'.
+
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.wrapper_pkg.all;
+
+entity core_wrapper is
+   generic (
+      DATA_WIDTH : positive := 8
+   );
+   port (
+      clk_i    : in std_logic;
+      cfg_i    : in std_logic_vector(11 downto 0);
+      data_i   : in std_logic_vector(DATA_WIDTH - 1 downto 0);
+      status_o : out std_logic_vector(4 downto 0)
+   );
+end entity;
+
+architecture wrapper of core_wrapper is
+
+   signal cfg_i_rec    : t_cfg;
+   signal status_o_rec : t_status;
+
+begin
+
+   cfg_i_rec <= to_cfg(cfg_i);
+   status_o <= to_slv(status_o_rec);
+
+   u_core : entity work.core
+      generic map (
+         DATA_WIDTH => DATA_WIDTH
+      )
+      port map (
+         clk_i    => clk_i,
+         cfg_i    => cfg_i_rec,
+         data_i   => data_i,
+         status_o => status_o_rec
+      );
+
+end architecture;
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package wrapper_pkg is
+   --thdl:gen
+   type t_cfg is record
+      en   : std_logic;
+      mode : std_logic_vector(2 downto 0);
+      cnt  : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen
+   type t_status is record
+      busy : boolean;
+      errs : natural; --thdl: width=4
+   end record;
+
+   --thdl:start checksum=82436c61
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   constant C_CFG_WIDTH : natural := 12;
+   constant C_CFG_EN_HI : natural := 11;
+   constant C_CFG_EN_LO : natural := 11;
+   constant C_CFG_MODE_HI : natural := 10;
+   constant C_CFG_MODE_LO : natural := 8;
+   constant C_CFG_CNT_HI : natural := 7;
+   constant C_CFG_CNT_LO : natural := 0;
+
+   function to_cfg(slv : std_logic_vector(11 downto 0)) return t_cfg;
+   function to_slv(cfg : t_cfg) return std_logic_vector;
+   function to_str(cfg : t_cfg; add_names : boolean := false) return string;
+
+   constant C_STATUS_WIDTH : natural := 5;
+   constant C_STATUS_BUSY_HI : natural := 4;
+   constant C_STATUS_BUSY_LO : natural := 4;
+   constant C_STATUS_ERRS_HI : natural := 3;
+   constant C_STATUS_ERRS_LO : natural := 0;
+
+   function to_status(slv : std_logic_vector(4 downto 0)) return t_status;
+   function to_slv(status : t_status) return std_logic_vector;
+   function to_str(status : t_status; add_names : boolean := false) return string;
+
+   --thdl:end
+
+end package;
+
+package body wrapper_pkg is
+
+   --thdl:start checksum=0bbf78f8
+   -- Below code was automatically generated with the thdl tool.
+   -- Do not modify it by hand, unless you really know what you do.
+   -- More info on https://github.com/m-kru/go-thdl.
+
+   function to_cfg(slv : std_logic_vector(11 downto 0)) return t_cfg is
+      variable cfg : t_cfg;
+   begin
+      cfg.en := slv(11);
+      cfg.mode := slv(10 downto 8);
+      cfg.cnt := unsigned(slv(7 downto 0));
+      return cfg;
+   end function;
+
+   function to_slv(cfg : t_cfg) return std_logic_vector is
+      variable slv : std_logic_vector(11 downto 0);
+   begin
+      slv(11) := cfg.en;
+      slv(10 downto 8) := cfg.mode;
+      slv(7 downto 0) := std_logic_vector(cfg.cnt);
+      return slv;
+   end function;
+
+   function to_str(cfg : t_cfg; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "en => " & to_string(cfg.en) & ", " & "mode => " & to_string(cfg.mode) & ", " & "cnt => " & to_string(cfg.cnt) & ")";
+      end if;
+      return "(" & to_string(cfg.en) & ", " & to_string(cfg.mode) & ", " & to_string(cfg.cnt) & ")";
+   end function;
+
+   function to_status(slv : std_logic_vector(4 downto 0)) return t_status is
+      variable status : t_status;
+   begin
+      if slv(4) = '1' then
+         status.busy := true;
+      elsif slv(4) = '0' then
+         status.busy := false;
+      else
+         report "bit 4: cannot convert " & to_string(slv(4)) & " to boolean type" severity failure;
+      end if;
+      status.errs := to_integer(unsigned(slv(3 downto 0)));
+      return status;
+   end function;
+
+   function to_slv(status : t_status) return std_logic_vector is
+      variable slv : std_logic_vector(4 downto 0);
+   begin
+      if status.busy then slv(4) := '1'; else slv(4) := '0'; end if;
+      slv(3 downto 0) := std_logic_vector(to_unsigned(status.errs, 32));
+      return slv;
+   end function;
+
+   function to_str(status : t_status; add_names : boolean := false) return string is
+   begin
+      if add_names then
+         return "(" & "busy => " & to_string(status.busy) & ", " & "errs => " & to_string(status.errs) & ")";
+      end if;
+      return "(" & to_string(status.busy) & ", " & to_string(status.errs) & ")";
+   end function;
+
+   --thdl:end
+
+end package body;
+
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.wrapper_pkg.all;
+
+entity core is
+   generic (
+      DATA_WIDTH : positive := 8
+   );
+   port (
+      clk_i    : in  std_logic;
+      cfg_i    : in  t_cfg;
+      data_i   : in  std_logic_vector(DATA_WIDTH - 1 downto 0);
+      status_o : out work.wrapper_pkg.t_status
+   );
+end entity;
+
+architecture rtl of core is
+begin
+end architecture;
@@ -0,0 +1,43 @@
+library ieee;
+   use ieee.std_logic_1164.all;
+   use ieee.numeric_std.all;
+
+package wrapper_pkg is
+   --thdl:gen
+   type t_cfg is record
+      en   : std_logic;
+      mode : std_logic_vector(2 downto 0);
+      cnt  : unsigned(7 downto 0);
+   end record;
+
+   --thdl:gen
+   type t_status is record
+      busy : boolean;
+      errs : natural; --thdl: width=4
+   end record;
+end package;
+
+package body wrapper_pkg is
+end package body;
+
+library ieee;
+   use ieee.std_logic_1164.all;
+
+library work;
+   use work.wrapper_pkg.all;
+
+entity core is
+   generic (
+      DATA_WIDTH : positive := 8
+   );
+   port (
+      clk_i    : in  std_logic;
+      cfg_i    : in  t_cfg;
+      data_i   : in  std_logic_vector(DATA_WIDTH - 1 downto 0);
+      status_o : out work.wrapper_pkg.t_status
+   );
+end entity;
+
+architecture rtl of core is
+begin
+end architecture;
@@ -0,0 +1,11 @@
+architecture rtl of e is
+begin
+   u_fifo : entity work.fifo
+   generic map (
+      G_DEPTH   => 16,
+      G_USE_RAM => 1
+   )
+   port map (
+      clk_i => clk_i
+   );
+end architecture;
@@ -0,0 +1,11 @@
+architecture rtl of e is
+begin
+   u_uart : entity work.uart
+   generic map (
+      G_SYS_CLK_FREQ_HZ => C_ETH_CLK_FREQ_HZ,
+      G_BAUD_RATE       => 115_200
+   )
+   port map (
+      clk_i => sys_clk
+   );
+end architecture;
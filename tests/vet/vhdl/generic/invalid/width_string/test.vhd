@@ -0,0 +1,8 @@
+architecture rtl of e is
+begin
+   u_fifo : entity work.fifo
+   generic map (G_DATA_WIDTH => "32", G_DEPTH => 16)
+   port map (
+      clk_i => clk_i
+   );
+end architecture;
@@ -0,0 +1,14 @@
+architecture rtl of e is
+begin
+   u_uart : entity work.uart
+   generic map (
+      G_SYS_CLK_FREQ_HZ => C_SYS_CLK_FREQ_HZ,
+      G_DATA_WIDTH      => 8,
+      G_USE_PARITY      => true,
+      G_NAME            => "uart0"
+   )
+   port map (
+      clk_i    => sys_clk,
+      enable_i => '1'
+   );
+end architecture;
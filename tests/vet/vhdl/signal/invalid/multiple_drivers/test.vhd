@@ -0,0 +1,23 @@
+architecture rtl of e is
+   signal cnt : unsigned(7 downto 0);
+   signal flag : std_logic;
+begin
+   inc : process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         cnt <= cnt + 1;
+      end if;
+   end process;
+
+   clr : process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         if clr_i = '1' then cnt <= (others => '0'); end if;
+      end if;
+   end process;
+
+   flag <= '1' when cnt = 0 else '0';
+   flag <= clr_i;
+
+   q_o <= flag;
+end architecture;
@@ -0,0 +1,12 @@
+architecture rtl of e is
+   signal en : std_logic;
+begin
+   process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         if en = '1' then
+            q_o <= d_i;
+         end if;
+      end if;
+   end process;
+end architecture;
@@ -0,0 +1,10 @@
+architecture rtl of e is
+   signal debug : std_logic;
+begin
+   process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         debug <= d_i;
+      end if;
+   end process;
+end architecture;
@@ -0,0 +1,6 @@
+architecture rtl of e is
+   signal a, b : std_logic;
+begin
+   b <= d_i;
+   q_o <= b;
+end architecture;
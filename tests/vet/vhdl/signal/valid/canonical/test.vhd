@@ -0,0 +1,40 @@
+architecture rtl of e is
+   signal const_sig : std_logic := '1';
+   signal vec       : std_logic_vector(1 downto 0);
+   signal inst_out  : std_logic;
+   signal sel       : std_logic_vector(1 downto 0);
+   signal state     : t_state;
+
+   function f(x : std_logic) return std_logic is
+   begin
+      return not x;
+   end function;
+begin
+   p0 : process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         vec(0) <= f(const_sig);
+         case state is
+            when IDLE => state <= RUN;
+            when others => state <= IDLE;
+         end case;
+      end if;
+   end process;
+
+   p1 : process (clk_i) is
+   begin
+      if rising_edge(clk_i) then
+         vec(1) <= '0';
+      end if;
+   end process;
+
+   with vec select sel <=
+      "00" when "11",
+      inst_out & '1' when others;
+
+   u_inst : entity work.x
+   port map (
+      a => sel,
+      b => inst_out
+   );
+end architecture;
@@ -0,0 +1,7 @@
+architecture rtl of e is
+   signal debug : std_logic; --thdl:ignore
+   --thdl:ignore
+   signal spare : std_logic;
+begin
+   debug <= d_i;
+end architecture;
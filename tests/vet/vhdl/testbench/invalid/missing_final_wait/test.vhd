@@ -0,0 +1,39 @@
+entity counter_tb is
+end entity;
+
+architecture test of counter_tb is
+   signal clk : std_logic := '0';
+   signal rst : std_logic;
+   signal en  : std_logic;
+begin
+   clk_gen : process is
+   begin
+      clk <= '0';
+      wait for 5 ns;
+      clk <= '1';
+      wait for 5 ns;
+   end process;
+
+   stimulus : process is
+   begin
+      rst <= '1';
+      en <= '0';
+      wait for 100 ns;
+      rst <= '0';
+      en <= '1';
+      wait for 1 us;
+   end process;
+
+   u_dut : entity work.counter
+   port map (
+      clk_i => clk,
+      rst_i => rst,
+      en_i  => en
+   );
+
+   main : process is
+   begin
+      wait for 2 us;
+      std.env.finish;
+   end process;
+end architecture;
@@ -0,0 +1,13 @@
+entity tb_counter is
+end entity;
+
+architecture test of tb_counter is
+   signal clk : std_logic := '0';
+begin
+   clk <= not clk after 5 ns;
+
+   u_dut : entity work.counter
+   port map (
+      clk_i => clk
+   );
+end architecture;
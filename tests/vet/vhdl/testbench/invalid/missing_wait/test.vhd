@@ -0,0 +1,17 @@
+entity tb is
+end entity;
+
+architecture test of tb is
+   signal cnt : natural := 0;
+begin
+   main : process is
+   begin
+      cnt <= cnt + 1;
+   end process;
+
+   checker : process is
+   begin
+      wait until cnt = 10;
+      std.env.finish;
+   end process;
+end architecture;
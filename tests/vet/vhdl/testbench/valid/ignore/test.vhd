@@ -0,0 +1,41 @@
+entity tb_counter is
+end entity;
+
+architecture test of tb_counter is
+   signal clk : std_logic := '0';
+   signal rst : std_logic;
+   signal en  : std_logic;
+   signal cnt : unsigned(7 downto 0);
+begin
+   clk <= not clk after 5 ns;
+
+   stimulus : process is
+   begin
+      rst <= '1';
+      en <= '0';
+      wait for 100 ns;
+      rst <= '0';
+      en <= '1';
+      wait; --thdl:ignore
+   end process;
+
+   --thdl:ignore
+   checker : process is
+   begin
+      assert cnt < 200;
+   end process;
+
+   main : process is
+   begin
+      wait for 1 ms;
+      std.env.finish; --thdl:ignore
+   end process;
+
+   u_dut : entity work.counter
+   port map (
+      clk_i => clk,
+      rst_i => rst,
+      en_i  => en,
+      cnt_o => cnt
+   );
+end architecture;
@@ -0,0 +1,16 @@
+entity tb is
+end entity;
+
+architecture test of tb is
+   signal a : std_logic;
+   signal b : std_logic;
+begin
+   p : process is
+   begin
+      a <= '1';
+      b <= '0';
+      wait for 10 ns;
+   end process;
+
+   q <= a and b;
+end architecture;
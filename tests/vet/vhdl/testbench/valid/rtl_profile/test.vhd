@@ -0,0 +1,19 @@
+entity pulse_gen is
+end entity;
+
+architecture rtl of pulse_gen is
+   signal a : std_logic;
+   signal b : std_logic;
+begin
+   p : process is
+   begin
+      a <= '1';
+      b <= '0';
+      wait for 10 ns;
+      a <= '0';
+      b <= '1';
+      wait for 10 ns;
+   end process;
+
+   q_o <= a and b;
+end architecture;